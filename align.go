@@ -0,0 +1,159 @@
+package blox
+
+import (
+	"bufio"
+	"strings"
+)
+
+// HorizontalAlignment of text, see Alignment.
+type HorizontalAlignment uint8
+
+const (
+	AlignLeft HorizontalAlignment = iota
+	AlignCenter
+	AlignRight
+	AlignJustify // Stretch lines to the full width by widening the spaces between words.
+)
+
+// VerticalAlignment of text, see Alignment.
+type VerticalAlignment uint8
+
+const (
+	AlignTop VerticalAlignment = iota
+	AlignMiddle
+	AlignBottom
+)
+
+// Alignment of text within a rectangle used by PutTextAligned and
+// PutTextAlignedIn. By default each line is aligned on its own. If Block is
+// true, the text is aligned as a block the width of its widest line with the
+// lines left aligned (or justified) within the block, which keeps for example
+// a box drawn with characters intact.
+type Alignment struct {
+	Horizontal HorizontalAlignment
+	Vertical   VerticalAlignment
+	Block      bool
+}
+
+// PutTextAligned writes text aligned within the entire canvas, or within the
+// region if b is a Region (see Region and PutTextAlignedIn). Line width is
+// measured in terminal columns (see StringWidth) and lines are separated by
+// LineSpacing. Lines wider than the canvas are cropped at the edge they
+// overflow. The origin (see PushOrigin) does not affect the alignment.
+// AlignJustify stretches every line except the last one and lines followed by
+// an empty line (the last line of a paragraph). The cursor is left in the
+// first column on the row after the last line.
+func (b *Blox) PutTextAligned(text string, alignment Alignment) *Blox {
+	y := b.putTextAligned(text, alignment)
+	return b.Move(-b.originX, y-b.originY)
+}
+
+// PutTextAlignedIn writes text aligned within the rectangle at column x, row
+// y that is w columns wide and h rows high, see PutTextAligned. Text outside
+// of the rectangle is clipped. The cursor is left at column x on the row after
+// the last line.
+func (b *Blox) PutTextAlignedIn(text string, x int, y int, w int, h int, alignment Alignment) *Blox {
+	next := b.Region(x, y, w, h).putTextAligned(text, alignment)
+	return b.Move(x, y+next)
+}
+
+// putTextAligned writes text aligned within b and returns the row after the
+// last line which may be outside of b.
+func (b *Blox) putTextAligned(text string, alignment Alignment) int {
+	if b.Rows == 0 || b.Columns == 0 {
+		return 0
+	}
+	lines := make([]string, 0, LineCount(text))
+	s := bufio.NewScanner(strings.NewReader(text))
+	for s.Scan() {
+		lines = append(lines, s.Text())
+	}
+	if len(lines) == 0 {
+		return 0
+	}
+	height := (len(lines)-1)*b.LineSpacing + 1
+	y := 0
+	switch alignment.Vertical {
+	case AlignMiddle:
+		y = (b.Rows - height) / 2
+	case AlignBottom:
+		y = b.Rows - height
+	}
+	width := b.Columns
+	blockX := 0
+	if alignment.Block {
+		width = 0
+		for _, line := range lines {
+			if w := StringWidth(line); w > width {
+				width = w
+			}
+		}
+		blockX = alignedX(alignment.Horizontal, width, b.Columns)
+	}
+	for i, line := range lines {
+		if y >= b.Rows {
+			break
+		}
+		if y >= 0 {
+			lastInParagraph := i == len(lines)-1 || strings.TrimSpace(lines[i+1]) == ""
+			if alignment.Horizontal == AlignJustify && !lastInParagraph {
+				line = justify(line, width)
+			}
+			x := blockX
+			if !alignment.Block {
+				x = alignedX(alignment.Horizontal, StringWidth(line), width)
+			}
+			runes := []rune(line)
+			if x < 0 {
+				runes = cropLeftWidth(runes, -x)
+				x = 0
+			}
+			b.Move(x-b.originX, y-b.originY).PutLine(runes)
+		}
+		y += b.LineSpacing
+	}
+	return y
+}
+
+// alignedX returns the column where something width columns wide starts when
+// aligned within space columns.
+func alignedX(alignment HorizontalAlignment, width int, space int) int {
+	switch alignment {
+	case AlignCenter:
+		return (space - width) / 2
+	case AlignRight:
+		return space - width
+	}
+	return 0
+}
+
+// justify returns line stretched to width columns by distributing space
+// between its words, leftmost gaps get the extra space first. Lines with less
+// than two words or wider than width are returned as-is.
+func justify(line string, width int) string {
+	words := strings.Fields(line)
+	if len(words) < 2 {
+		return line
+	}
+	used := 0
+	for _, word := range words {
+		used += StringWidth(word)
+	}
+	gaps := len(words) - 1
+	space := width - used
+	if space < gaps {
+		return line
+	}
+	var sb strings.Builder
+	for i, word := range words {
+		sb.WriteString(word)
+		if i < gaps {
+			n := space / gaps
+			if i < space%gaps {
+				n++
+			}
+			sb.WriteString(strings.Repeat(" ", n))
+		}
+	}
+	return sb.String()
+}
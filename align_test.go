@@ -0,0 +1,69 @@
+package blox_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/sa6mwa/blox"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPutTextAligned(t *testing.T) {
+	text := "one" + blox.LineBreak + "three"
+	cases := []struct {
+		alignment blox.Alignment
+		expect    []string
+	}{
+		{blox.Alignment{}, []string{"one", "three", "", ""}},
+		{blox.Alignment{Horizontal: blox.AlignCenter}, []string{"   one", "  three", "", ""}},
+		{blox.Alignment{Horizontal: blox.AlignRight, Vertical: blox.AlignBottom}, []string{"", "", "       one", "     three"}},
+		{blox.Alignment{Horizontal: blox.AlignRight, Block: true}, []string{"     one", "     three", "", ""}},
+		{blox.Alignment{Horizontal: blox.AlignCenter, Vertical: blox.AlignMiddle, Block: true}, []string{"", "  one", "  three", ""}},
+	}
+	for i, tc := range cases {
+		b := blox.New().SetColumnsAndRows(10, 4).SetTrimRightSpaces(true)
+		b.PutTextAligned(text, tc.alignment)
+		assert.Equal(t, tc.expect, b.Strings(), "case %d", i)
+	}
+}
+
+func TestPutTextAlignedJustify(t *testing.T) {
+	text := blox.WrapString("Justified text is stretched to the full width except the last line.", 20)
+	b := blox.New().SetColumnsAndRows(20, 5).Trim()
+	b.PutTextAligned(text, blox.Alignment{Horizontal: blox.AlignJustify})
+	expect := []string{
+		"Justified   text  is",
+		"stretched   to   the",
+		"full   width  except",
+		"the last line.",
+	}
+	assert.Equal(t, expect, b.Strings())
+}
+
+func TestPutTextAlignedIn(t *testing.T) {
+	b := blox.New().SetColumnsAndRows(20, 5).Trim().DrawBox(0, 0, 20, 5, blox.LineASCII)
+	b.PutTextAlignedIn("Title", 1, 1, 18, 1, blox.Alignment{Horizontal: blox.AlignCenter}).
+		PutTextAlignedIn("123"+blox.LineBreak+"45678", 1, 2, 18, 2, blox.Alignment{Horizontal: blox.AlignRight})
+	expect := "+------------------+" + blox.LineBreak +
+		"|      Title       |" + blox.LineBreak +
+		"|               123|" + blox.LineBreak +
+		"|             45678|" + blox.LineBreak +
+		"+------------------+" + blox.LineBreak
+	assert.Equal(t, expect, b.String())
+	assert.Equal(t, 1, b.Cursor.X)
+	assert.Equal(t, 4, b.Cursor.Y)
+
+	// Lines wider than the rectangle are cropped.
+	b.PutTextAlignedIn("abcdefghijklmnopqrstuvwxyz", 1, 1, 18, 1, blox.Alignment{Horizontal: blox.AlignRight})
+	assert.Equal(t, "|ijklmnopqrstuvwxyz|", b.Strings()[1])
+}
+
+func ExampleBlox_PutTextAligned() {
+	b := blox.New().Trim().SetColumnsAndRows(30, 3).DrawBox(0, 0, 30, 3, blox.LineLight)
+	b.Region(1, 1, 28, 1).PutTextAligned("CENTERED HEADING", blox.Alignment{Horizontal: blox.AlignCenter})
+	fmt.Print(b.String())
+	// Output:
+	// ┌────────────────────────────┐
+	// │      CENTERED HEADING      │
+	// └────────────────────────────┘
+}
@@ -0,0 +1,309 @@
+package blox
+
+import (
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ColorProfile is the color capability of the terminal or log the canvas is
+// rendered for. Colors the profile can not show are degraded to the closest
+// color the profile supports, see Color.Degrade.
+type ColorProfile uint8
+
+const (
+	ProfileNone      ColorProfile = iota // No colors, attributes only.
+	Profile16                            // The 16 basic ANSI colors.
+	Profile256                           // The xterm 256 color palette.
+	ProfileTrueColor                     // 24-bit colors.
+)
+
+const (
+	csi      string = "\x1b["
+	sgrReset string = csi + "0m"
+)
+
+// ansi16 is the RGB representation of the 16 basic colors (xterm defaults).
+var ansi16 = [16][3]uint8{
+	{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0},
+	{0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+	{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+	{92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+// cubeLevels are the 6 intensities of each component in the 6x6x6 color cube
+// of the 256 color palette (index 16-231).
+var cubeLevels = [6]uint8{0, 95, 135, 175, 215, 255}
+
+// PaletteRGB returns the red, green and blue components of index n in the
+// xterm 256 color palette.
+func PaletteRGB(n uint8) (uint8, uint8, uint8) {
+	switch {
+	case n < 16:
+		return ansi16[n][0], ansi16[n][1], ansi16[n][2]
+	case n < 232:
+		n -= 16
+		return cubeLevels[n/36], cubeLevels[(n/6)%6], cubeLevels[n%6]
+	}
+	g := 8 + (n-232)*10
+	return g, g, g
+}
+
+// ToRGB returns the red, green and blue components of c. The default color
+// has no RGB representation and returns ok false.
+func (c Color) ToRGB() (r, g, b uint8, ok bool) {
+	switch c.Mode {
+	case ColorModeBasic, ColorModeIndexed:
+		r, g, b = PaletteRGB(c.Index)
+		return r, g, b, true
+	case ColorModeRGB:
+		return c.R, c.G, c.B, true
+	}
+	return 0, 0, 0, false
+}
+
+// Degrade returns c converted to the closest color available in profile p
+// (true color → 256 → 16 → none). Colors already supported by p are returned
+// as-is.
+func (c Color) Degrade(p ColorProfile) Color {
+	if c.Mode == ColorModeDefault {
+		return c
+	}
+	switch p {
+	case ProfileTrueColor:
+		return c
+	case Profile256:
+		if c.Mode == ColorModeRGB {
+			return Indexed(nearest256(c.R, c.G, c.B))
+		}
+		return c
+	case Profile16:
+		if c.Mode == ColorModeBasic {
+			return c
+		}
+		if c.Mode == ColorModeIndexed && c.Index < 16 {
+			return Basic(c.Index)
+		}
+		r, g, b, _ := c.ToRGB()
+		return Basic(nearest16(r, g, b))
+	}
+	return DefaultColor
+}
+
+// nearest256 returns the index in the 256 color palette closest to r, g, b
+// from either the color cube or the grayscale ramp.
+func nearest256(r, g, b uint8) uint8 {
+	cube := func(v uint8) uint8 {
+		if v < 48 {
+			return 0
+		}
+		if v < 115 {
+			return 1
+		}
+		return (v - 35) / 40
+	}
+	cr, cg, cb := cube(r), cube(g), cube(b)
+	cubeIndex := 16 + 36*cr + 6*cg + cb
+	avg := (int(r) + int(g) + int(b)) / 3
+	grayIndex := uint8(232)
+	if avg > 238 {
+		grayIndex = 255
+	} else if avg > 8 {
+		grayIndex = uint8(232 + (avg-3)/10)
+	}
+	if colorDistance(r, g, b, grayIndex) < colorDistance(r, g, b, cubeIndex) {
+		return grayIndex
+	}
+	return cubeIndex
+}
+
+// nearest16 returns the basic color (0-15) closest to r, g, b.
+func nearest16(r, g, b uint8) uint8 {
+	best := uint8(0)
+	bestDistance := -1
+	for i := uint8(0); i < 16; i++ {
+		d := colorDistance(r, g, b, i)
+		if bestDistance < 0 || d < bestDistance {
+			best = i
+			bestDistance = d
+		}
+	}
+	return best
+}
+
+// colorDistance returns the squared euclidean distance between r, g, b and
+// palette index n.
+func colorDistance(r, g, b uint8, n uint8) int {
+	pr, pg, pb := PaletteRGB(n)
+	dr := int(r) - int(pr)
+	dg := int(g) - int(pg)
+	db := int(b) - int(pb)
+	return dr*dr + dg*dg + db*db
+}
+
+// sgrAttributes maps each attribute to its SGR parameter.
+var sgrAttributes = []struct {
+	attr  Attribute
+	param string
+}{
+	{AttrBold, "1"},
+	{AttrDim, "2"},
+	{AttrItalic, "3"},
+	{AttrUnderline, "4"},
+	{AttrReverse, "7"},
+}
+
+// appendColorParams appends the SGR parameters selecting color c as
+// foreground (or background if background is true) to params.
+func appendColorParams(params []string, c Color, background bool) []string {
+	switch c.Mode {
+	case ColorModeDefault:
+		if background {
+			return append(params, "49")
+		}
+		return append(params, "39")
+	case ColorModeBasic:
+		base := 30
+		if c.Index >= 8 {
+			base = 90
+		}
+		if background {
+			base += 10
+		}
+		return append(params, strconv.Itoa(base+int(c.Index&7)))
+	case ColorModeIndexed:
+		if background {
+			return append(params, "48", "5", strconv.Itoa(int(c.Index)))
+		}
+		return append(params, "38", "5", strconv.Itoa(int(c.Index)))
+	}
+	if background {
+		return append(params, "48", "2", strconv.Itoa(int(c.R)), strconv.Itoa(int(c.G)), strconv.Itoa(int(c.B)))
+	}
+	return append(params, "38", "2", strconv.Itoa(int(c.R)), strconv.Itoa(int(c.G)), strconv.Itoa(int(c.B)))
+}
+
+// degradeStyle returns s with both colors degraded to profile p.
+func degradeStyle(s Style, p ColorProfile) Style {
+	s.Foreground = s.Foreground.Degrade(p)
+	s.Background = s.Background.Degrade(p)
+	return s
+}
+
+// sgrTransition returns the shortest SGR escape sequence changing the terminal
+// from style from to style to. Returns an empty string if they are equal.
+func sgrTransition(from Style, to Style) string {
+	if from == to {
+		return ""
+	}
+	if to.IsZero() {
+		return sgrReset
+	}
+	params := make([]string, 0, 8)
+	if from.Attributes&^to.Attributes != 0 {
+		// There is no portable way to turn off a single attribute (bold and dim
+		// share the same reset parameter), start over from a reset.
+		params = append(params, "0")
+		from = Style{}
+	}
+	for _, a := range sgrAttributes {
+		if to.Attributes&a.attr != 0 && from.Attributes&a.attr == 0 {
+			params = append(params, a.param)
+		}
+	}
+	if to.Foreground != from.Foreground {
+		params = appendColorParams(params, to.Foreground, false)
+	}
+	if to.Background != from.Background {
+		params = appendColorParams(params, to.Background, true)
+	}
+	if len(params) == 0 {
+		return ""
+	}
+	return csi + strings.Join(params, ";") + "m"
+}
+
+// ANSILines returns each row of the canvas as a string with SGR escape
+// sequences for the style of each cell. Only changes between adjacent cells
+// are emitted and every line with styled content ends in a reset. Colors are
+// degraded to what profile supports. Trailing space and final empty lines are
+// trimmed according to TrimRightSpaces and TrimFinalEmptyLines (see Cells).
+func (b *Blox) ANSILines(profile ColorProfile) []string {
+	rows := b.Cells()
+	lines := make([]string, 0, len(rows))
+	var buf []byte
+	for _, row := range rows {
+		buf = appendANSI(buf[:0], row, profile)
+		lines = append(lines, string(buf))
+	}
+	return lines
+}
+
+// appendANSI appends row to buf with SGR escape sequences for profile, ending
+// in a reset if the row has styled content.
+func appendANSI(buf []byte, row []Cell, profile ColorProfile) []byte {
+	current := Style{}
+	for _, c := range row {
+		next := degradeStyle(c.Style, profile)
+		buf = append(buf, sgrTransition(current, next)...)
+		current = next
+		buf = utf8.AppendRune(buf, c.Rune)
+		for _, r := range c.Combining {
+			buf = utf8.AppendRune(buf, r)
+		}
+	}
+	if !current.IsZero() {
+		buf = append(buf, sgrReset...)
+	}
+	return buf
+}
+
+// ANSI is the styled counterpart of String, returns the canvas with SGR escape
+// sequences where each line ends in LineBreak. See ANSILines.
+func (b *Blox) ANSI(profile ColorProfile) string {
+	var sb strings.Builder
+	for _, line := range b.ANSILines(profile) {
+		sb.WriteString(line)
+		sb.WriteString(LineBreak)
+	}
+	return sb.String()
+}
+
+// FprintANSI writes the canvas with SGR escape sequences (see ANSI) to o. A
+// write error is recorded and returned by Err.
+func (b *Blox) FprintANSI(o io.Writer, profile ColorProfile) *Blox {
+	if err := b.WriteANSI(o, profile); err != nil {
+		b.setErr(err)
+	}
+	return b
+}
+
+// WriteANSI writes the canvas with SGR escape sequences (see ANSI) to o one
+// chunk of rows at a time and returns any write error instead of recording it
+// like FprintANSI.
+func (b *Blox) WriteANSI(o io.Writer, profile ColorProfile) error {
+	b = b.composite()
+	rows := b.cellRows()
+	var row []Cell
+	var buf []byte
+	var n int64
+	var err error
+	for r := 0; r < rows; r++ {
+		row = b.appendCellRow(row[:0], r)
+		buf = appendANSI(buf, row, profile)
+		buf = append(buf, LineBreak...)
+		if buf, err = writeChunk(o, buf, &n, false); err != nil {
+			return err
+		}
+	}
+	_, err = writeChunk(o, buf, &n, true)
+	return err
+}
+
+// PrintANSI writes the canvas with SGR escape sequences (see ANSI) to
+// os.Stdout.
+func (b *Blox) PrintANSI(profile ColorProfile) *Blox {
+	return b.FprintANSI(os.Stdout, profile)
+}
@@ -0,0 +1,60 @@
+package blox_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/sa6mwa/blox"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestANSILines(t *testing.T) {
+	b := blox.New().SetColumnsAndRows(10, 3).Trim()
+	b.SetForeground(blox.Red).PutLine([]rune("AB")).SetAttributes(blox.AttrBold).PutLine([]rune("C")).
+		ResetPen().PutLine([]rune("D")).Move(0, 1).PutText("plain")
+
+	lines := b.ANSILines(blox.ProfileTrueColor)
+	assert.Equal(t, []string{
+		"\x1b[31mAB\x1b[1mC\x1b[0mD",
+		"plain",
+	}, lines)
+
+	b.Move(0, 1).SetPen(blox.Style{Attributes: blox.AttrUnderline | blox.AttrBold}).PutLine([]rune("p")).
+		SetAttributes(blox.AttrBold).PutLine([]rune("l"))
+	lines = b.ANSILines(blox.ProfileTrueColor)
+	// Removing an attribute starts over from a reset.
+	assert.Equal(t, "\x1b[1;4mp\x1b[0;1ml\x1b[0main", lines[1])
+}
+
+func TestANSIColorProfiles(t *testing.T) {
+	b := blox.New().SetColumnsAndRows(1, 1).SetForeground(blox.RGB(255, 0, 0)).SetBackground(blox.Indexed(21)).PutChar('X')
+	assert.Equal(t, "\x1b[38;2;255;0;0;48;5;21mX\x1b[0m"+blox.LineBreak, b.ANSI(blox.ProfileTrueColor))
+	assert.Equal(t, "\x1b[38;5;196;48;5;21mX\x1b[0m"+blox.LineBreak, b.ANSI(blox.Profile256))
+	assert.Equal(t, "\x1b[91;44mX\x1b[0m"+blox.LineBreak, b.ANSI(blox.Profile16))
+	assert.Equal(t, "X"+blox.LineBreak, b.ANSI(blox.ProfileNone))
+}
+
+func TestColorDegrade(t *testing.T) {
+	assert.Equal(t, blox.Indexed(16), blox.RGB(0, 0, 0).Degrade(blox.Profile256))
+	assert.Equal(t, blox.Indexed(231), blox.RGB(255, 255, 255).Degrade(blox.Profile256))
+	assert.Equal(t, blox.Indexed(244), blox.RGB(128, 128, 128).Degrade(blox.Profile256))
+	assert.Equal(t, blox.Basic(3), blox.Indexed(3).Degrade(blox.Profile16))
+	assert.Equal(t, blox.BrightWhite, blox.RGB(250, 250, 250).Degrade(blox.Profile16))
+	assert.Equal(t, blox.DefaultColor, blox.Green.Degrade(blox.ProfileNone))
+	assert.Equal(t, blox.Green, blox.Green.Degrade(blox.Profile16))
+}
+
+func TestANSIBackgroundNotTrimmed(t *testing.T) {
+	b := blox.New().SetColumnsAndRows(4, 2).Trim().SetBackground(blox.Blue).PutText("  ")
+	assert.Equal(t, "\x1b[44m  \x1b[0m"+blox.LineBreak, b.ANSI(blox.Profile16))
+}
+
+func ExampleBlox_ANSI() {
+	b := blox.New().Trim().SetColumnsAndRows(20, 2)
+	b.SetForeground(blox.Green).PutText("OK").ResetPen().MoveRight(3).PutText("done")
+	fmt.Print(strings.ReplaceAll(b.ANSI(blox.Profile16), "\x1b", "ESC"))
+	// Output:
+	// ESC[32mOKESC[0m
+	//    done
+}
@@ -0,0 +1,106 @@
+package blox
+
+// PutBlox copies the whole canvas of src (including its visible layers) onto
+// b at the cursor position and moves the cursor to the row below the copied
+// block like PutText does. See Blit.
+func (b *Blox) PutBlox(src *Blox) *Blox {
+	x, y := b.Cursor.X, b.Cursor.Y
+	return b.Blit(src, 0, 0, src.Columns, src.Rows, x, y).Move(x, y+src.Rows)
+}
+
+// Blit copies the rectangle at column srcX, row srcY of src that is w columns
+// wide and h rows high onto b with the upper left hand corner at column dstX,
+// row dstY. Unlike src.String() followed by PutText, runes are copied cell by
+// cell with their grapheme clusters and styles intact (the pen of b is not
+// used) and trailing space is copied regardless of trim settings. Visible
+// layers of src are composited before copying. src may be b itself (or a
+// Region of it) and the rectangles may overlap, see also CopyRect. Everything
+// outside of either canvas is clipped and wide characters cut in half by the
+// clipping are replaced by a space. If b is in transparent mode (see
+// SetTransparent), transparent runes in src are skipped. The cursor is not
+// moved.
+func (b *Blox) Blit(src *Blox, srcX int, srcY int, w int, h int, dstX int, dstY int) *Blox {
+	if w <= 0 || h <= 0 {
+		return b
+	}
+	// Copy first, src may share the canvas of b.
+	b.pasteCells(src.composite().copyCells(srcX, srcY, w, h), dstX, dstY, true)
+	return b
+}
+
+// cellRect is a copy of the cells of a rectangle of the canvas.
+type cellRect struct {
+	w         int
+	h         int
+	cells     []Cell // Cells row by row, Rune is 0 outside of the canvas.
+	continued []bool // The cell right of each row continues a wide character.
+}
+
+// copyCells returns a copy of the rectangle at column x, row y that is w
+// columns wide and h rows high.
+func (b *Blox) copyCells(x int, y int, w int, h int) cellRect {
+	c := cellRect{w: w, h: h, cells: make([]Cell, w*h), continued: make([]bool, h)}
+	s := b.root()
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			if idx := b.cellIndex(x+i, y+j); idx >= 0 {
+				c.cells[j*w+i] = Cell{Rune: s.Canvas[idx], Combining: s.Combining[idx], Style: b.styleAtIndex(idx)}
+			}
+		}
+		c.continued[j] = b.isContinuation(x+w, y+j)
+	}
+	return c
+}
+
+// cell returns the cell at column x, row y of the copy and false if it was
+// outside of the canvas. Half of a wide character is replaced by a space if
+// the other half is not in the copy or is not written with it: first is true
+// if the cell to the left is not written, last if the cell to the right is
+// not written.
+func (c cellRect) cell(x int, y int, first bool, last bool) (Cell, bool) {
+	cell := c.cells[y*c.w+x]
+	switch {
+	case cell.Rune == 0:
+		return cell, false
+	case cell.Rune == ContinuationCell:
+		if x == 0 || first {
+			cell.Rune = ' '
+		}
+	case x+1 < c.w && c.cells[y*c.w+x+1].Rune == ContinuationCell, x+1 == c.w && c.continued[y]:
+		if x+1 == c.w || last {
+			cell.Rune, cell.Combining = ' ', nil
+		}
+	}
+	return cell, true
+}
+
+// putCell writes cell with its style and grapheme cluster to column x, row y.
+// The pen is changed to the style of cell.
+func (b *Blox) putCell(x int, y int, cell Cell) {
+	b.Pen = cell.Style
+	if i := b.setCell(x, y, cell.Rune); i >= 0 {
+		for _, m := range cell.Combining {
+			b.attachCombining(i, m)
+		}
+	}
+}
+
+// pasteCells writes the copy c to column x, row y, skipping transparent runes
+// if transparent is true. Wide characters cut in half by the edge of the copy
+// or the canvas are replaced by a space.
+func (b *Blox) pasteCells(c cellRect, x int, y int, transparent bool) {
+	pen := b.Pen
+	for j := 0; j < c.h; j++ {
+		for i := 0; i < c.w; i++ {
+			if b.cellIndex(x+i, y+j) < 0 {
+				continue
+			}
+			cell, ok := c.cell(i, j, b.cellIndex(x+i-1, y+j) < 0, b.cellIndex(x+i+1, y+j) < 0)
+			if !ok || transparent && len(cell.Combining) == 0 && b.isTransparent([]rune{cell.Rune}) {
+				continue
+			}
+			b.putCell(x+i, y+j, cell)
+		}
+	}
+	b.Pen = pen
+}
@@ -0,0 +1,50 @@
+package blox_test
+
+import (
+	"testing"
+
+	"github.com/sa6mwa/blox"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPutBlox(t *testing.T) {
+	inner := blox.New().SetColumnsAndRows(4, 2).Trim()
+	inner.SetForeground(blox.Green).PutText("ab" + blox.LineBreak + "cd")
+
+	page := blox.New().SetColumnsAndRows(8, 4).Trim()
+	page.PutText("########" + blox.LineBreak + "########" + blox.LineBreak + "########")
+	page.Move(1, 1).PutBlox(inner).PutText("x")
+
+	// Trailing spaces of inner are copied even though inner trims them.
+	expect := "########" + blox.LineBreak +
+		"#ab  ###" + blox.LineBreak +
+		"#cd  ###" + blox.LineBreak +
+		" x" + blox.LineBreak
+	assert.Equal(t, expect, page.String())
+	assert.Equal(t, blox.Green, page.StyleAt(1, 1).Foreground)
+	assert.True(t, page.StyleAt(3, 1).IsZero())
+}
+
+func TestBlit(t *testing.T) {
+	src := blox.New().SetColumnsAndRows(6, 2).PutText("日本語" + blox.LineBreak + "á b c")
+	dst := blox.New().SetColumnsAndRows(6, 2).Trim().PutText("......" + blox.LineBreak + "......")
+
+	// Clipped at the right edge of dst, the wide character is cut in half.
+	dst.Blit(src, 0, 0, 6, 2, 1, 0)
+	assert.Equal(t, ".日本"+blox.LineBreak+".á b c"+blox.LineBreak, dst.String())
+
+	// Source rectangle starting in the middle of a wide character.
+	dst.Blit(src, 1, 0, 3, 1, 0, 1)
+	assert.Equal(t, " 本b c", dst.Strings()[1])
+
+	// Transparent mode and negative destination, c overwrites half of 日.
+	dst.SetTransparent(true).Blit(src, 0, 1, 6, 1, -2, 0)
+	assert.Equal(t, "b c本", dst.Strings()[0])
+
+	// Overlapping rectangles on the same canvas.
+	b := blox.New().SetColumnsAndRows(5, 2).PutText("abcd" + blox.LineBreak + "efgh")
+	b.Blit(b, 0, 0, 4, 2, 1, 0)
+	assert.Equal(t, []string{"aabcd", "eefgh"}, b.Strings())
+	b.Blit(b.Region(1, 0, 4, 2), 1, 0, 3, 2, 0, 0)
+	assert.Equal(t, []string{"bcdcd", "fghgh"}, b.Strings())
+}
@@ -0,0 +1,228 @@
+package blox
+
+// BlockMode selects how the pixels of a Blocks surface map onto cells.
+type BlockMode uint8
+
+const (
+	BlockHalf     BlockMode = iota // 1x2 pixels per cell drawn with ▀ ▄ █, square pixels.
+	BlockQuadrant                  // 2x2 pixels per cell drawn with ▘ ▝ ▖ ▗ ▚ etc.
+)
+
+// blockRunes is the block element for each combination of pixels set in a
+// cell, bit 0 is the upper left, bit 1 the upper right, bit 2 the lower left
+// and bit 3 the lower right pixel.
+var blockRunes = [16]rune{
+	' ', '▘', '▝', '▀', '▖', '▌', '▞', '▛',
+	'▗', '▚', '▐', '▜', '▄', '▙', '▟', '█',
+}
+
+// pixel is a pixel of a Blocks surface.
+type pixel struct {
+	color Color
+	set   bool
+}
+
+// Blocks is a surface of colored pixels drawn with Unicode block elements,
+// either half blocks (1x2 pixels per cell) or quadrants (2x2 pixels per cell),
+// for small images and heat maps in a canvas that is otherwise text. Pixel 0,0
+// is the upper left hand corner and pixels outside of the surface are ignored.
+// Render the surface onto a Blox with DrawBlocks.
+//
+// Each pixel has a color of its own, but a cell can only show two colors (the
+// foreground and background of its style). Half blocks always show both
+// pixels of a cell in their colors, in quadrant mode a cell with more than two
+// colors shows the two most common ones and every other pixel in whichever of
+// them is closest.
+type Blocks struct {
+	mode    BlockMode
+	columns int
+	rows    int
+	pixels  []pixel
+}
+
+// NewBlocks returns an empty block surface covering columns x rows cells of the
+// canvas in mode (BlockHalf or BlockQuadrant).
+func NewBlocks(columns int, rows int, mode BlockMode) *Blocks {
+	p := &Blocks{
+		mode:    mode,
+		columns: maxInt(columns, 0),
+		rows:    maxInt(rows, 0),
+	}
+	w, h := p.Size()
+	p.pixels = make([]pixel, w*h)
+	return p
+}
+
+// Size returns the width and height of the surface in pixels.
+func (p *Blocks) Size() (width int, height int) {
+	if p.mode == BlockQuadrant {
+		return p.columns * 2, p.rows * 2
+	}
+	return p.columns, p.rows * 2
+}
+
+// index returns the index of pixel x, y or -1 if it is outside of the surface.
+func (p *Blocks) index(x int, y int) int {
+	w, h := p.Size()
+	if x < 0 || y < 0 || x >= w || y >= h {
+		return -1
+	}
+	return y*w + x
+}
+
+// SetPixel sets pixel x, y in the optional color, the foreground color of the
+// pen when rendered if color is omitted or DefaultColor.
+func (p *Blocks) SetPixel(x int, y int, color ...Color) *Blocks {
+	if i := p.index(x, y); i >= 0 {
+		p.pixels[i] = pixel{set: true}
+		if len(color) > 0 {
+			p.pixels[i].color = color[0]
+		}
+	}
+	return p
+}
+
+// ClearPixel clears pixel x, y.
+func (p *Blocks) ClearPixel(x int, y int) *Blocks {
+	if i := p.index(x, y); i >= 0 {
+		p.pixels[i] = pixel{}
+	}
+	return p
+}
+
+// Pixel returns the color of pixel x, y and true if it is set.
+func (p *Blocks) Pixel(x int, y int) (Color, bool) {
+	if i := p.index(x, y); i >= 0 {
+		return p.pixels[i].color, p.pixels[i].set
+	}
+	return DefaultColor, false
+}
+
+// Clear clears all pixels.
+func (p *Blocks) Clear() *Blocks {
+	for i := range p.pixels {
+		p.pixels[i] = pixel{}
+	}
+	return p
+}
+
+// Line sets the pixels of a straight line from x0, y0 to x1, y1 in the
+// optional color, see SetPixel.
+func (p *Blocks) Line(x0 int, y0 int, x1 int, y1 int, color ...Color) *Blocks {
+	for _, pt := range linePoints(x0, y0, x1, y1) {
+		p.SetPixel(pt.X, pt.Y, color...)
+	}
+	return p
+}
+
+// Cell returns the block element and style of cell column, row of the surface
+// drawn with pen: set pixels in the foreground color (or their own color) and
+// cleared pixels in the background color of pen.
+func (p *Blocks) Cell(column int, row int, pen Style) Cell {
+	if column < 0 || row < 0 || column >= p.columns || row >= p.rows {
+		return Cell{Rune: ' ', Style: pen}
+	}
+	// The pixels of the cell in the order of the bits of blockRunes, a half
+	// block has its upper and lower pixel in bit 0 and 1 until the end.
+	var cell [4]pixel
+	used := 4
+	if p.mode == BlockQuadrant {
+		for i := range cell {
+			cell[i] = p.pixels[p.index(column*2+i%2, row*2+i/2)]
+		}
+	} else {
+		cell[0] = p.pixels[p.index(column, row*2)]
+		cell[1] = p.pixels[p.index(column, row*2+1)]
+		used = 2
+	}
+	var distinct []pixel
+	var counts []int
+	for i := 0; i < used; i++ {
+		if cell[i].set && cell[i].color.IsDefault() {
+			cell[i].color = pen.Foreground
+		}
+		found := false
+		for j, d := range distinct {
+			if d == cell[i] {
+				counts[j]++
+				found = true
+			}
+		}
+		if !found {
+			distinct = append(distinct, cell[i])
+			counts = append(counts, 1)
+		}
+	}
+	// Most common first, keeping the order of the pixels on ties.
+	for i := 1; i < len(distinct); i++ {
+		for j := i; j > 0 && counts[j] > counts[j-1]; j-- {
+			distinct[j], distinct[j-1] = distinct[j-1], distinct[j]
+			counts[j], counts[j-1] = counts[j-1], counts[j]
+		}
+	}
+	var fg, bg pixel
+	for _, d := range distinct {
+		if d.set {
+			fg = d
+			break
+		}
+	}
+	if !fg.set {
+		return Cell{Rune: ' ', Style: pen}
+	}
+	for _, d := range distinct {
+		if d != fg {
+			bg = d
+			break
+		}
+	}
+	style := pen
+	style.Foreground = fg.color
+	if bg.set {
+		style.Background = bg.color
+	}
+	mask := 0
+	for i := 0; i < used; i++ {
+		if cell[i] == fg || cell[i] != bg && cell[i].set && closer(cell[i].color, fg.color, style.Background) {
+			mask |= 1 << i
+		}
+	}
+	if p.mode == BlockHalf {
+		mask = mask&1*3 | mask>>1&1*12
+	}
+	return Cell{Rune: blockRunes[mask], Style: style}
+}
+
+// closer returns true if c is closer to a than to b in RGB or if they can not
+// be compared (one of them is the default color).
+func closer(c Color, a Color, b Color) bool {
+	r, g, bl, ok := c.ToRGB()
+	ar, ag, ab, aok := a.ToRGB()
+	br, bg, bb, bok := b.ToRGB()
+	if !ok || !aok || !bok {
+		return true
+	}
+	distance := func(r2, g2, b2 uint8) int {
+		dr, dg, db := int(r)-int(r2), int(g)-int(g2), int(bl)-int(b2)
+		return dr*dr + dg*dg + db*db
+	}
+	return distance(ar, ag, ab) <= distance(br, bg, bb)
+}
+
+// DrawBlocks renders the block surface p onto the canvas with the upper left
+// hand corner at column x, row y using the pen for pixels without a color of
+// their own and for the background. Cells without any pixels set are written
+// as spaces, so they are skipped in transparent mode (see SetTransparent).
+// Everything outside of the canvas is clipped and the cursor is not moved.
+func (b *Blox) DrawBlocks(p *Blocks, x int, y int) *Blox {
+	pen := b.Pen
+	for row := 0; row < p.rows; row++ {
+		for column := 0; column < p.columns; column++ {
+			c := p.Cell(column, row, pen)
+			b.Pen = c.Style
+			b.plot(x+column, y+row, c.Rune)
+		}
+	}
+	b.Pen = pen
+	return b
+}
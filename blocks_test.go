@@ -0,0 +1,81 @@
+package blox_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/sa6mwa/blox"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlocksHalf(t *testing.T) {
+	p := blox.NewBlocks(3, 1, blox.BlockHalf)
+	w, h := p.Size()
+	assert.Equal(t, 3, w)
+	assert.Equal(t, 2, h)
+
+	p.SetPixel(0, 0, blox.Red).SetPixel(1, 1).SetPixel(2, 0, blox.Red).SetPixel(2, 1, blox.Blue).SetPixel(3, 0)
+	c, ok := p.Pixel(0, 0)
+	assert.True(t, ok)
+	assert.Equal(t, blox.Red, c)
+	_, ok = p.Pixel(0, 1)
+	assert.False(t, ok)
+
+	pen := blox.Style{Foreground: blox.Green, Attributes: blox.AttrBold}
+	assert.Equal(t, blox.Cell{Rune: '▀', Style: pen.WithForeground(blox.Red)}, p.Cell(0, 0, pen))
+	assert.Equal(t, blox.Cell{Rune: '▄', Style: pen}, p.Cell(1, 0, pen))
+	assert.Equal(t, blox.Cell{Rune: '▀', Style: pen.WithForeground(blox.Red).WithBackground(blox.Blue)}, p.Cell(2, 0, pen))
+	assert.Equal(t, blox.Cell{Rune: ' ', Style: pen}, p.Cell(3, 0, pen))
+
+	p.SetPixel(1, 0)
+	assert.Equal(t, blox.Cell{Rune: '█', Style: pen}, p.Cell(1, 0, pen))
+	p.ClearPixel(1, 0).ClearPixel(1, 1)
+	assert.Equal(t, blox.Cell{Rune: ' ', Style: pen}, p.Cell(1, 0, pen))
+
+	b := blox.New().SetColumnsAndRows(5, 2).Trim().Move(0, 1).SetPen(pen)
+	b.DrawBlocks(p, 1, 0)
+	assert.Equal(t, []string{" ▀ ▀"}, b.Strings())
+	assert.Equal(t, pen, b.Pen)
+	assert.Equal(t, 0, b.Cursor.X, "the cursor is not moved")
+	assert.Equal(t, 1, b.Cursor.Y)
+	cells := b.Cells()
+	assert.Equal(t, blox.Red, cells[0][1].Style.Foreground)
+	assert.Equal(t, blox.Blue, cells[0][3].Style.Background)
+}
+
+func TestBlocksQuadrant(t *testing.T) {
+	p := blox.NewBlocks(2, 1, blox.BlockQuadrant)
+	w, h := p.Size()
+	assert.Equal(t, 4, w)
+	assert.Equal(t, 2, h)
+
+	p.Line(0, 0, 1, 1).Line(3, 0, 2, 1, blox.Yellow)
+	pen := blox.Style{Background: blox.Black}
+	assert.Equal(t, blox.Cell{Rune: '▚', Style: pen}, p.Cell(0, 0, pen))
+	assert.Equal(t, blox.Cell{Rune: '▞', Style: pen.WithForeground(blox.Yellow)}, p.Cell(1, 0, pen))
+
+	p.Clear().SetPixel(0, 0, blox.RGB(255, 0, 0)).SetPixel(1, 0, blox.RGB(255, 0, 0))
+	p.SetPixel(0, 1, blox.RGB(0, 0, 255)).SetPixel(1, 1, blox.RGB(200, 0, 40))
+	assert.Equal(t, blox.Cell{Rune: '▜', Style: blox.Style{
+		Foreground: blox.RGB(255, 0, 0),
+		Background: blox.RGB(0, 0, 255),
+	}}, p.Cell(0, 0, blox.Style{}), "three colors in a cell")
+
+	b := blox.New().SetColumnsAndRows(3, 1).Trim().PutText("abc").SetTransparent(true)
+	b.DrawBlocks(blox.NewBlocks(3, 1, blox.BlockQuadrant).SetPixel(3, 1), 0, 0)
+	assert.Equal(t, []string{"a▗c"}, b.Strings())
+}
+
+func ExampleBlox_DrawBlocks() {
+	p := blox.NewBlocks(8, 2, blox.BlockQuadrant)
+	for x := 0; x < 16; x++ {
+		for y := 3 - x/4; y < 4; y++ {
+			p.SetPixel(x, y, blox.RGB(uint8(x/2*32), 200, 0))
+		}
+	}
+	b := blox.New().SetColumnsAndRows(8, 2).DrawBlocks(p, 0, 0)
+	fmt.Println(b.String())
+	// Output:
+	//     ▄▄██
+	// ▄▄██████
+}
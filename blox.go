@@ -3,7 +3,7 @@ package blox
 import (
 	"bufio"
 	"bytes"
-	"fmt"
+	"io"
 	"os"
 	"strings"
 	"unicode"
@@ -30,6 +30,42 @@ type Blox struct {
 	TrimRightSpaces     bool
 	TrimFinalEmptyLines bool
 	Canvas              []rune
+	Transparent         bool    // Do not write TransparentRune to the canvas.
+	TransparentRune     rune    // Transparent rune, space if 0.
+	Pen                 Style   // Style used when writing to the canvas.
+	Styles              []Style // Style of each rune in Canvas.
+	// Combining holds the runes following the rune at the same index in
+	// Canvas when a cell is an extended grapheme cluster of several runes
+	// (combining marks, joiners, emoji modifiers, etc).
+	Combining map[int][]rune
+	Layers    []*Layer // Layers composited on top of Canvas, see Layer.
+	Strict    bool     // Record writes outside of the canvas as errors, see Err.
+	// AutoGrowColumns and AutoGrowRows grow the canvas when something is
+	// written outside of it, up to MaxColumns and MaxRows unless 0 (see
+	// SetAutoGrow).
+	AutoGrowColumns bool
+	AutoGrowRows    bool
+	MaxColumns      int
+	MaxRows         int
+	// Scrollback holds the rows scrolled out of the top of the canvas, at
+	// most ScrollbackLimit, see SetScrollback.
+	Scrollback      [][]Cell
+	ScrollbackLimit int
+	err             error // First error recorded, see Err.
+	lastPut         int   // Canvas index+1 of the last rune written by PutChar.
+	parent          *Blox // Parent canvas if this is a Region.
+	offsetX         int   // Column of the Region in the parent.
+	offsetY         int   // Row of the Region in the parent.
+	canvasColumns   int   // Columns of the layout of Canvas.
+	canvasRows      int   // Rows of the layout of Canvas.
+	originX         int   // Column of the origin, see PushOrigin.
+	originY         int   // Row of the origin.
+	origins         []CursorPosition
+	// clampedColumn and clampedRow record that only the column or only the
+	// row was clamped by Move, which Cursor alone can not tell apart from a
+	// negative position on the last column or row.
+	clampedColumn bool
+	clampedRow    bool
 }
 
 type CursorPosition struct {
@@ -56,26 +92,69 @@ func (b *Blox) Wipe() *Blox {
 		b.Canvas[i] = 0
 	}
 	b.Canvas = b.Canvas[:0]
+	for i := 0; i < len(b.Styles); i++ {
+		b.Styles[i] = Style{}
+	}
+	b.Styles = b.Styles[:0]
+	b.Combining = nil
 	return b
 }
 
+// ResizeCanvas resizes the canvas to Columns and Rows, for example after
+// changing them directly. Content keeps its column and row, content outside
+// of the new size is dropped. See Resize to keep the content anchored
+// elsewhere.
 func (b *Blox) ResizeCanvas() *Blox {
+	if b.parent != nil {
+		// A Region has no canvas of its own.
+		return b.Move(b.Cursor.X, b.Cursor.Y)
+	}
+	b.relayout(b.Columns, b.Rows, 0, 0, ' ')
+	b.resizeLayers()
+	return b.Move(b.Cursor.X, b.Cursor.Y)
+}
+
+// resizeCanvas resizes the flat canvas to Columns*Rows without moving any
+// content, which only keeps the layout if the number of columns is unchanged.
+func (b *Blox) resizeCanvas() {
 	have := len(b.Canvas)
 	need := b.Columns * b.Rows
+	haveStyles := len(b.Styles)
 	if need > cap(b.Canvas) {
 		tmp := make([]rune, need)
 		copy(tmp, b.Canvas)
+		tmpStyles := make([]Style, need)
+		copy(tmpStyles, b.Styles)
+		combining := b.Combining
 		b.Wipe()
 		b.Canvas = tmp
+		b.Styles = tmpStyles
+		b.Combining = combining
 	} else {
 		b.Canvas = b.Canvas[:need]
+		if need > cap(b.Styles) {
+			tmpStyles := make([]Style, need)
+			copy(tmpStyles, b.Styles)
+			b.Styles = tmpStyles
+		} else {
+			b.Styles = b.Styles[:need]
+		}
 	}
 	if need > have {
 		for i := 0; i < need-have; i++ {
 			b.Canvas[have+i] = ' '
 		}
 	}
-	return b.Move(b.Cursor.X, b.Cursor.Y)
+	for i := haveStyles; i < need; i++ {
+		b.Styles[i] = Style{}
+	}
+	for i := range b.Combining {
+		if i >= need {
+			delete(b.Combining, i)
+		}
+	}
+	b.canvasColumns = b.Columns
+	b.canvasRows = b.Rows
 }
 
 func (b *Blox) SetColumns(columns int) *Blox {
@@ -112,6 +191,30 @@ func (b *Blox) SetTrimFinalEmptyLines(trim bool) *Blox {
 	return b
 }
 
+// SetTransparent enables or disables transparent pasting. When enabled,
+// PutChar, PutLine, PutLines, PutText and PutTextRightAligned skip over the
+// transparent rune instead of writing it, leaving what is already on the canvas
+// visible. The transparent rune is space unless specified with the optional r.
+func (b *Blox) SetTransparent(transparent bool, r ...rune) *Blox {
+	b.Transparent = transparent
+	if len(r) > 0 {
+		b.TransparentRune = r[0]
+	}
+	return b
+}
+
+// isTransparent returns true if the grapheme cluster is not to be written to
+// the canvas in transparent mode.
+func (b *Blox) isTransparent(cluster []rune) bool {
+	if !b.Transparent || len(cluster) != 1 {
+		return false
+	}
+	if b.TransparentRune == 0 {
+		return cluster[0] == ' '
+	}
+	return cluster[0] == b.TransparentRune
+}
+
 // SetTrim allow you to enable/disable trimming of trailing spaces and empty
 // lines with the same function.
 func (b *Blox) SetTrim(trim bool) *Blox {
@@ -127,25 +230,39 @@ func (b *Blox) Trim() *Blox {
 }
 
 // Move to a column/row position on the canvas where x is column and y is
-// row, upp left hand corner is 0,0.
+// row, upp left hand corner is 0,0 (or the origin, see PushOrigin). Negative
+// positions are allowed, Cursor.OffCanvas is true and what is written there
+// is clipped. Positions beyond the right edge or the bottom row are clamped
+// to the last column or row (unless the canvas can grow, see SetAutoGrow).
 func (b *Blox) Move(x int, y int) *Blox {
-	if x >= b.Columns {
+	b.lastPut = 0
+	ax, ay := x+b.originX, y+b.originY
+	b.Cursor.OffCanvas = ax < 0 || ay < 0
+	b.clampedColumn = ax >= b.Columns && ay < b.Rows
+	b.clampedRow = ay >= b.Rows && ax < b.Columns
+	if ax >= b.Columns {
 		b.Cursor.OffCanvas = true
-		if b.Columns > 0 {
-			b.Cursor.X = b.Columns - 1
-		} else {
-			b.Cursor.X = 0
+		switch {
+		case b.canGrowColumns(ax + 1):
+			// Left off canvas until something is written, see SetAutoGrow.
+			b.Cursor.X = x
+		case b.Columns > 0:
+			b.Cursor.X = b.Columns - 1 - b.originX
+		default:
+			b.Cursor.X = -b.originX
 		}
 	} else {
-		b.Cursor.OffCanvas = false
 		b.Cursor.X = x
 	}
-	if y >= b.Rows {
+	if ay >= b.Rows {
 		b.Cursor.OffCanvas = true
-		if b.Rows > 0 {
-			b.Cursor.Y = b.Rows - 1
-		} else {
-			b.Cursor.Y = 0
+		switch {
+		case b.canGrowRows(ay + 1):
+			b.Cursor.Y = y
+		case b.Rows > 0:
+			b.Cursor.Y = b.Rows - 1 - b.originY
+		default:
+			b.Cursor.Y = -b.originY
 		}
 	} else {
 		b.Cursor.Y = y
@@ -161,26 +278,54 @@ func (b *Blox) MoveY(y int) *Blox {
 	return b.Move(b.Cursor.X, y)
 }
 
+// MoveRight moves the cursor n (default 1) characters right. A wide character
+// counts as one even though it occupies two cells.
 func (b *Blox) MoveRight(n ...int) *Blox {
 	step := 1
 	if len(n) > 0 && n[0] > step {
 		step = n[0]
 	}
-	return b.Move(b.Cursor.X+step, b.Cursor.Y)
+	x := b.Cursor.X
+	for i := 0; i < step; i++ {
+		x++
+		for b.isContinuation(x, b.Cursor.Y) {
+			x++
+		}
+	}
+	return b.Move(x, b.Cursor.Y)
 }
 
+// MoveLeft moves the cursor n (default 1) characters left, but not beyond the
+// first column unless the cursor already is left of it (see Move), then it
+// moves n columns. A wide character counts as one even though it occupies two
+// cells.
 func (b *Blox) MoveLeft(n ...int) *Blox {
 	step := 1
 	if len(n) > 0 && n[0] > step {
 		step = n[0]
 	}
-	if b.Cursor.X >= step {
-		if b.Cursor.OffCanvas {
-			return b.Move(b.Cursor.X-step+1, b.Cursor.Y)
+	x := b.Cursor.X
+	if x < 0 {
+		return b.Move(x-step, b.Cursor.Y)
+	}
+	if b.columnClamped() {
+		// The cursor is right of the last column.
+		x++
+	}
+	for i := 0; i < step && x > 0; i++ {
+		x--
+		for x > 0 && b.isContinuation(x, b.Cursor.Y) {
+			x--
 		}
-		return b.Move(b.Cursor.X-step, b.Cursor.Y)
 	}
-	return b.Move(0, b.Cursor.Y)
+	return b.Move(x, b.Cursor.Y)
+}
+
+// isContinuation returns true if the cell at column x, row y is the second
+// half of a wide character.
+func (b *Blox) isContinuation(x int, y int) bool {
+	i := b.cellIndex(x, y)
+	return i >= 0 && b.root().Canvas[i] == ContinuationCell
 }
 
 func (b *Blox) MoveDown(n ...int) *Blox {
@@ -191,40 +336,153 @@ func (b *Blox) MoveDown(n ...int) *Blox {
 	return b.Move(b.Cursor.X, b.Cursor.Y+step)
 }
 
+// MoveUp moves the cursor n (default 1) rows up, but not beyond the first row
+// unless the cursor already is above it (see Move), then it moves n rows.
 func (b *Blox) MoveUp(n ...int) *Blox {
 	step := 1
 	if len(n) > 0 && n[0] > step {
 		step = n[0]
 	}
-	if b.Cursor.Y >= step {
-		return b.Move(b.Cursor.X, b.Cursor.Y-step)
+	if b.Cursor.Y >= 0 && b.Cursor.Y < step {
+		return b.Move(b.Cursor.X, 0)
 	}
-	return b.Move(b.Cursor.X, 0)
+	return b.Move(b.Cursor.X, b.Cursor.Y-step)
 }
 
+// PutLine writes runes at the cursor position, one extended grapheme cluster
+// (see Graphemes) per cell, and moves the cursor to the right of them.
 func (b *Blox) PutLine(runes []rune) *Blox {
-	for _, r := range runes {
-		b.PutChar(r)
+	for len(runes) > 0 {
+		n := graphemeLength(runes)
+		b.putCluster(runes[:n])
+		runes = runes[n:]
 	}
 	return b
 }
 
+// PutChar writes r at the cursor position with the current pen and moves the
+// cursor right. Wide characters (see IsWide) occupy two cells where the second
+// is a ContinuationCell. Combining marks (see IsCombining) are attached to the
+// previously written character without moving the cursor.
 func (b *Blox) PutChar(r rune) *Blox {
-	if b.Cursor.X < b.Columns {
+	return b.putCluster([]rune{r})
+}
+
+// putCluster writes a grapheme cluster to the cell at the cursor position,
+// the first rune in Canvas and the rest in Combining. Wide clusters occupy two
+// cells.
+func (b *Blox) putCluster(cluster []rune) *Blox {
+	if !IsCombining(cluster[0]) && cluster[0] != '\n' && cluster[0] != '\r' {
+		x, y := b.Cursor.X, b.Cursor.Y
+		ax, ay := x+b.originX, y+b.originY
+		if width := maxInt(clusterWidth(cluster), 1); ax+width > b.Columns || ay >= b.Rows {
+			if b.grow(ax+width, ay+1) {
+				b.Move(x, y)
+			}
+		}
+	}
+	if b.Columns == 0 || b.Rows == 0 {
+		b.offCanvas("%q", string(cluster))
+		return b
+	}
+	if b.Cursor.X+b.originX < b.Columns {
 		switch {
-		case r == '\n', r == '\r':
+		case cluster[0] == '\n', cluster[0] == '\r':
 			return b
+		case IsCombining(cluster[0]):
+			if b.lastPut > 0 {
+				for _, r := range cluster {
+					b.attachCombining(b.lastPut-1, r)
+				}
+			}
+			return b
+		}
+		x, y := b.Cursor.X, b.Cursor.Y
+		width := 1
+		if clusterWidth(cluster) == 2 {
+			width = 2
 		}
-		if !b.Cursor.OffCanvas {
-			b.Canvas[b.CurrentIndex()] = r
+		written := -1
+		offCanvas := b.Cursor.OffCanvas
+		if b.isTransparent(cluster) {
+			b.movePast(x+width, y)
+			return b
+		}
+		if !offCanvas {
+			if width == 2 && x+b.originX+1 >= b.Columns {
+				// Half a wide character does not fit in the last column.
+				b.setCell(x, y, ' ')
+			} else {
+				written = b.setCell(x, y, cluster[0])
+				if width == 2 {
+					b.setCell(x+1, y, ContinuationCell)
+				}
+				for _, r := range cluster[1:] {
+					b.attachCombining(written, r)
+				}
+			}
+		} else {
+			b.offCanvas("%q", string(cluster))
+			if width == 2 && x+b.originX == -1 {
+				// Half a wide character is clipped at the left edge.
+				b.setCell(x+1, y, ' ')
+			}
 		}
-		b.MoveRight()
+		b.movePast(x+width, y)
+		b.lastPut = written + 1
 	}
 	return b
 }
 
+// setCell writes r with the current pen to column x, row y. Writing over half
+// of a wide character blanks the other half. Returns the canvas index written
+// to or -1 if x/y is outside the canvas.
+func (b *Blox) setCell(x int, y int, r rune) int {
+	if !b.fit(x, y) {
+		b.offCanvas("%q at column %d, row %d", r, x, y)
+		return -1
+	}
+	i := b.cellIndex(x, y)
+	s, x, y := b.rootPosition(x, y)
+	if s.Canvas[i] == ContinuationCell && r != ContinuationCell {
+		if prev := s.index(x-1, y); prev >= 0 {
+			s.Canvas[prev] = ' '
+			delete(s.Combining, prev)
+		}
+	}
+	if next := s.index(x+1, y); next >= 0 && s.Canvas[next] == ContinuationCell {
+		s.Canvas[next] = ' '
+	}
+	s.Canvas[i] = r
+	delete(s.Combining, i)
+	if i < len(s.Styles) {
+		s.Styles[i] = b.Pen
+	}
+	return i
+}
+
+// attachCombining adds r to the grapheme cluster at canvas index i.
+func (b *Blox) attachCombining(i int, r rune) {
+	s := b.root()
+	if s.Combining == nil {
+		s.Combining = make(map[int][]rune)
+	}
+	s.Combining[i] = append(s.Combining[i], r)
+}
+
+// appendCell appends the grapheme cluster at canvas index i to line.
+// Continuation cells of wide characters are skipped.
+func (b *Blox) appendCell(line []rune, i int) []rune {
+	s := b.root()
+	if s.Canvas[i] == ContinuationCell {
+		return line
+	}
+	line = append(line, s.Canvas[i])
+	return append(line, s.Combining[i]...)
+}
+
 func (b *Blox) PutLines(lines ...string) *Blox {
-	if b.Rows == 0 || b.Columns == 0 {
+	if b.noCanvas() {
 		return b
 	}
 	originX := b.Cursor.X
@@ -236,7 +494,7 @@ func (b *Blox) PutLines(lines ...string) *Blox {
 }
 
 func (b *Blox) PutText(text string) *Blox {
-	if b.Rows == 0 || b.Columns == 0 {
+	if b.noCanvas() {
 		return b
 	}
 	originX := b.Cursor.X
@@ -248,10 +506,10 @@ func (b *Blox) PutText(text string) *Blox {
 }
 
 func (b *Blox) PutTextRightAligned(text string) *Blox {
-	if b.Rows == 0 || b.Columns == 0 {
+	if b.noCanvas() {
 		return b
 	}
-	l := MaximumLineLength(text)
+	l := MaximumLineWidth(text)
 	alignedX := 0
 	cropBeginningBy := 0
 	if l > b.Columns {
@@ -259,77 +517,132 @@ func (b *Blox) PutTextRightAligned(text string) *Blox {
 	} else {
 		alignedX = b.Columns - l
 	}
+	alignedX -= b.originX
 	b.MoveX(alignedX)
 	s := bufio.NewScanner(strings.NewReader(text))
 	for s.Scan() {
 		line := []rune(s.Text())
 		if cropBeginningBy > 0 {
-			if len(line) > cropBeginningBy {
-				line = line[cropBeginningBy:]
-			} else {
-				line = line[0:0]
-			}
+			line = cropLeftWidth(line, cropBeginningBy)
 		}
 		b.PutLine(line).Move(alignedX, b.Cursor.Y+b.LineSpacing)
 	}
 	return b
 }
 
-func (b *Blox) FprintCanvas(o *os.File) *Blox {
-	_, err := o.Write([]byte(b.String()))
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v", err)
+// FprintCanvas writes the canvas (see String) to o. A write error is recorded
+// and returned by Err.
+func (b *Blox) FprintCanvas(o io.Writer) *Blox {
+	if err := b.WriteCanvas(o); err != nil {
+		b.setErr(err)
 	}
 	return b
 }
 
+// PrintCanvas writes the canvas to os.Stdout, see FprintCanvas.
 func (b *Blox) PrintCanvas() *Blox {
 	return b.FprintCanvas(os.Stdout)
 }
 
+// WriteCanvas writes the canvas (see String) to o and returns any write error
+// instead of recording it like FprintCanvas.
+func (b *Blox) WriteCanvas(o io.Writer) error {
+	_, err := b.WriteTo(o)
+	return err
+}
+
+// WriteTo implements io.WriterTo, writes the canvas to w as String would
+// format it, one chunk of rows at a time without first copying the entire
+// canvas (other than compositing visible layers, see Layer). Returns the
+// number of bytes written.
+func (b *Blox) WriteTo(w io.Writer) (int64, error) {
+	b = b.composite()
+	rows := b.outputRows()
+	var line []rune
+	var buf []byte
+	var n int64
+	var err error
+	for r := 0; r < rows; r++ {
+		line = b.appendRow(line[:0], r)
+		for _, c := range line {
+			buf = utf8.AppendRune(buf, c)
+		}
+		buf = append(buf, LineBreak...)
+		if buf, err = writeChunk(w, buf, &n, false); err != nil {
+			return n, err
+		}
+	}
+	_, err = writeChunk(w, buf, &n, true)
+	return n, err
+}
+
+// writeChunkSize is the number of bytes WriteTo and WriteANSI collect before
+// writing them to the io.Writer.
+const writeChunkSize = 32 * 1024
+
+// writeChunk writes buf to w once it holds writeChunkSize bytes (or whatever
+// it holds if final is true) and adds the number of bytes written to n.
+// Returns buf emptied if it was written.
+func writeChunk(w io.Writer, buf []byte, n *int64, final bool) ([]byte, error) {
+	if len(buf) == 0 || !final && len(buf) < writeChunkSize {
+		return buf, nil
+	}
+	written, err := w.Write(buf)
+	*n += int64(written)
+	return buf[:0], err
+}
+
 // Lines is the main function for producing printable output. Returns each row
 // as a slice of rune slices. Each line is with or without trailing space
 // depending on if TrimRightSpaces is true or false (SetTrimRightSpaces).
+// Visible layers are composited on top of the canvas (see Layer).
 func (b *Blox) Lines() [][]rune {
-	lines := make([][]rune, 0, b.Rows)
-	for r := 0; r < b.Rows; r++ {
-		line := make([]rune, 0, b.Columns)
-		for c := 0; c < b.Columns; c++ {
-			i := b.Index(c, r)
-			line = append(line, b.Canvas[i])
+	b = b.composite()
+	rows := b.outputRows()
+	lines := make([][]rune, 0, rows)
+	for r := 0; r < rows; r++ {
+		lines = append(lines, b.appendRow(make([]rune, 0, b.Columns), r))
+	}
+	return lines
+}
+
+// appendRow appends row y of the canvas to line, without trailing space if
+// TrimRightSpaces is true. Layers are not composited, see Lines.
+func (b *Blox) appendRow(line []rune, y int) []rune {
+	start := len(line)
+	for c := 0; c < b.Columns; c++ {
+		if i := b.index(c, y); i >= 0 {
+			line = b.appendCell(line, i)
+		} else {
+			// Part of a Region outside of its parent.
+			line = append(line, ' ')
 		}
-		if b.TrimRightSpaces {
-			for i := len(line); i > 0; i-- {
-				if unicode.IsSpace(line[i-1]) {
-					line = line[:i-1]
-				} else {
-					break
-				}
-			}
+	}
+	if b.TrimRightSpaces {
+		for len(line) > start && unicode.IsSpace(line[len(line)-1]) {
+			line = line[:len(line)-1]
 		}
-		lines = append(lines, line)
-	}
-	if b.TrimFinalEmptyLines {
-		for i := len(lines); i > 0; i-- {
-			empty := len(lines[i-1]) == 0
-			if !empty {
-				for x := 0; x < len(lines[i-1]); x++ {
-					if unicode.IsSpace(lines[i-1][x]) {
-						empty = true
-					} else {
-						empty = false
-						break
-					}
-				}
-			}
-			if empty {
-				lines = lines[:i-1]
-			} else {
-				break
+	}
+	return line
+}
+
+// outputRows returns the number of rows in the output, which is Rows unless
+// TrimFinalEmptyLines is true and the canvas ends in rows of white space.
+func (b *Blox) outputRows() int {
+	rows := b.Rows
+	if !b.TrimFinalEmptyLines {
+		return rows
+	}
+	var line []rune
+	for ; rows > 0; rows-- {
+		line = b.appendRow(line[:0], rows-1)
+		for _, r := range line {
+			if !unicode.IsSpace(r) {
+				return rows
 			}
 		}
 	}
-	return lines
+	return rows
 }
 
 // Returns each row in the canvas as a string slice.
@@ -375,43 +688,58 @@ func (b *Blox) Join(sep string) string {
 }
 
 // Return current index to write/read to/from on canvas based on current cursor
-// row/column position.
+// row/column position. Returns -1 if the cursor is off canvas (see Move). For
+// a Region the index is in the Canvas of the Blox it was created from.
 func (b *Blox) CurrentIndex() int {
-	if b.Cursor.X*b.Cursor.Y >= len(b.Canvas) {
-		if len(b.Canvas) > 0 {
-			return len(b.Canvas) - 1
-		} else {
-			return 0
-		}
+	if b.Cursor.OffCanvas {
+		return -1
 	}
-	return b.Cursor.Y*b.Columns + b.Cursor.X
+	return b.cellIndex(b.Cursor.X, b.Cursor.Y)
 }
 
 // Return index to write/read to/from on canvas by row/col representation (x is
-// column, y is row).
+// column, y is row, relative to the origin, see PushOrigin). Returns -1 if x/y
+// is outside the canvas. For a Region the index is in the Canvas of the Blox
+// it was created from.
 func (b *Blox) Index(x int, y int) int {
-	if x*y >= len(b.Canvas) {
-		if len(b.Canvas) > 0 {
-			return len(b.Canvas) - 1
-		} else {
-			return 0
-		}
-	}
-	return y*b.Columns + x
+	return b.cellIndex(x, y)
+}
 
+// cellIndex returns the index in Canvas for column x and row y relative to
+// the origin (see PushOrigin) or -1 if x/y is outside the canvas. For a
+// Region the index is in the Canvas of the Blox owning the storage (see
+// root).
+func (b *Blox) cellIndex(x int, y int) int {
+	return b.index(x+b.originX, y+b.originY)
+}
+
+// index is cellIndex for column x and row y relative to the upper left hand
+// corner of b regardless of the origin.
+func (b *Blox) index(x int, y int) int {
+	if x < 0 || y < 0 || x >= b.Columns || y >= b.Rows {
+		return -1
+	}
+	if b.parent != nil {
+		return b.parent.index(x+b.offsetX, y+b.offsetY)
+	}
+	i := y*b.Columns + x
+	if i >= len(b.Canvas) {
+		return -1
+	}
+	return i
 }
 
 // DrawSeparator draws a horizontal line with hyphens (-) at the current
 // row. You can change the default rune with the optional char.
 func (b *Blox) DrawSeparator(char ...rune) *Blox {
-	return b.DrawHorizontalLine(0, b.Columns, char...).MoveX(0).MoveDown()
+	return b.DrawHorizontalLine(-b.originX, b.Columns-1-b.originX, char...).MoveX(0).MoveDown()
 }
 
 // DrawSplit draws a vertical line with pipes (|) at the current column from the
 // top row to the bottom row of the canvas. You can change the default rune with
 // the optional char.
 func (b *Blox) DrawSplit(char ...rune) *Blox {
-	return b.DrawVerticalLine(0, b.Rows, char...)
+	return b.DrawVerticalLine(-b.originY, b.Rows-1-b.originY, char...)
 }
 
 // DrawHorizontalLine draws hyphens (-) horizontally between two X positions at
@@ -471,9 +799,73 @@ func (b *Blox) PopPos() *Blox {
 	return b
 }
 
-// RowAndColumnCount can be used to initialize a new canvas to fit
-// Move(0,0).PutText(text) with lines of text for example. Returns x, y
-// (column, row) where x represents the longest line count.
+// PushOrigin saves the current origin and moves it to column x, row y relative
+// to the current origin, then moves the cursor to the new origin. Until
+// PopOrigin, all positions (Move, DrawBox, Blit, Region, etc) are relative to
+// the origin, which lets a component draw at 0,0 wherever it is placed.
+// Everything outside of the canvas is clipped, the origin itself can be
+// outside of the canvas (for example to scroll a banner in from the left).
+// Columns and Rows are the size of the canvas regardless of the origin.
+func (b *Blox) PushOrigin(x int, y int) *Blox {
+	b.origins = append(b.origins, CursorPosition{X: b.originX, Y: b.originY})
+	b.originX += x
+	b.originY += y
+	return b.Move(0, 0)
+}
+
+// PopOrigin restores the origin saved by the last PushOrigin. The cursor stays
+// where it is on the canvas.
+func (b *Blox) PopOrigin() *Blox {
+	if len(b.origins) == 0 {
+		return b
+	}
+	x, y := b.Cursor.X+b.originX, b.Cursor.Y+b.originY
+	o := b.origins[len(b.origins)-1]
+	b.origins = b.origins[:len(b.origins)-1]
+	b.originX, b.originY = o.X, o.Y
+	return b.movePast(x-b.originX, y-b.originY)
+}
+
+// Origin returns the column and row of the origin (see PushOrigin) relative to
+// the upper left hand corner of the canvas.
+func (b *Blox) Origin() (int, int) {
+	return b.originX, b.originY
+}
+
+// pastCanvas returns true if the cursor is beyond the right edge or below the
+// bottom row, where nothing can be written.
+func (b *Blox) pastCanvas() bool {
+	if b.clampedColumn || b.clampedRow {
+		return true
+	}
+	return b.Cursor.OffCanvas && b.Cursor.X+b.originX >= 0 && b.Cursor.Y+b.originY >= 0
+}
+
+// columnClamped returns true if Move clamped the cursor to the last column.
+func (b *Blox) columnClamped() bool {
+	if !b.Cursor.OffCanvas || b.Cursor.X+b.originX != b.Columns-1 {
+		return false
+	}
+	return b.clampedColumn || !b.clampedRow && b.Cursor.Y+b.originY >= 0
+}
+
+// movePast moves the cursor to column x, row y like Move, but keeps it off
+// canvas if it was beyond the right edge or below the bottom row, as a row
+// clamped to the last row stays unwritable whatever the column.
+func (b *Blox) movePast(x int, y int) *Blox {
+	past, clampedRow := b.pastCanvas(), b.clampedRow
+	b.Move(x, y)
+	if past {
+		b.Cursor.OffCanvas = true
+		b.clampedRow = b.clampedRow || clampedRow
+	}
+	return b
+}
+
+// RowAndColumnCount returns x, y (column, row) where x is the number of runes
+// in the longest line and y the number of lines. PutText advances by display
+// width, use RowAndColumnWidth to size a canvas to fit Move(0,0).PutText(text)
+// when text may contain wide characters (CJK, emoji) or combining marks.
 func RowAndColumnCount(text string) (int, int) {
 	columnCount := 0
 	lineCount := 0
@@ -499,8 +891,9 @@ func LineCount(text string) int {
 	return lineCount
 }
 
-// MaximumLineLength returns number of runes in the longest line (can be used to
-// setup canvas Columns to fit text).
+// MaximumLineLength returns number of runes in the longest line. Use
+// MaximumLineWidth to setup canvas Columns to fit text, it counts terminal
+// columns like PutText does.
 func MaximumLineLength(text string) int {
 	lineLength := 0
 	s := bufio.NewScanner(strings.NewReader(text))
@@ -513,8 +906,9 @@ func MaximumLineLength(text string) int {
 	return lineLength
 }
 
-// CutLinesShort cuts several lines to maxLen and return the new text. Will trim
-// trailing space if trimTrailingSpace is true.
+// CutLinesShort cuts several lines to maxLen runes and return the new text.
+// Will trim trailing space if trimTrailingSpace is true. Use CutLinesShortWidth
+// to fit the lines in a number of terminal columns.
 func CutLinesShort(text string, maxLen int, trimTrailingSpace bool) string {
 	newText := make([]rune, 0, utf8.RuneCountInString(text))
 	s := bufio.NewScanner(strings.NewReader(text))
@@ -531,8 +925,9 @@ func CutLinesShort(text string, maxLen int, trimTrailingSpace bool) string {
 	return string(newText)
 }
 
-// CutLineShort cuts line after maxLen adding dots if addThreeDots is true.
-// Returns a shortened or the original string.
+// CutLineShort cuts line after maxLen runes adding dots if addThreeDots is
+// true. Returns a shortened or the original string. Use CutLineShortWidth to
+// fit the line in a number of terminal columns.
 func CutLineShort(line string, maxLen int, addThreeDots bool) string {
 	threeDots := '…'
 	if utf8.RuneCountInString(line) > maxLen {
@@ -653,3 +1048,19 @@ func WrapString(s string, lim uint) string {
 
 	return buf.String()
 }
+
+// maxInt returns the larger of a and b.
+func maxInt(a int, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// minInt returns the smaller of a and b.
+func minInt(a int, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
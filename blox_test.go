@@ -148,6 +148,12 @@ func TestMoveLeft(t *testing.T) {
 	b := blox.New().SetColumnsAndRows(3, 3).Move(0, 1)
 	c := blox.New().SetColumnsAndRows(3, 3).Move(2, 1).MoveLeft(2)
 	assert.Equal(t, b, c)
+	c.MoveLeft()
+	assert.Equal(t, b, c)
+
+	b = blox.New().SetColumnsAndRows(3, 3).Move(-3, 1)
+	c = blox.New().SetColumnsAndRows(3, 3).Move(-1, 1).MoveLeft(2)
+	assert.Equal(t, b, c, "left of the first column")
 }
 
 func TestMoveDown(t *testing.T) {
@@ -162,6 +168,25 @@ func TestMoveUp(t *testing.T) {
 	assert.Equal(t, b, c)
 	c.MoveUp(3)
 	assert.Equal(t, b, c)
+
+	b = blox.New().SetColumnsAndRows(3, 3).Move(1, -3)
+	c = blox.New().SetColumnsAndRows(3, 3).Move(1, -2).MoveUp()
+	assert.Equal(t, b, c, "above the first row")
+}
+
+func TestIndex(t *testing.T) {
+	b := blox.New().SetColumnsAndRows(3, 3)
+	assert.Equal(t, 5, b.Move(2, 1).CurrentIndex())
+	assert.Equal(t, -1, b.Move(-1, 1).CurrentIndex(), "off canvas")
+	assert.Equal(t, -1, b.Move(3, 1).CurrentIndex(), "clamped to the last column")
+	assert.Equal(t, 7, b.Index(1, 2))
+	assert.Equal(t, -1, b.Index(3, 0))
+	assert.Equal(t, -1, b.Index(0, -1))
+
+	r := b.Region(1, 1, 2, 2)
+	assert.Equal(t, 8, r.Move(1, 1).CurrentIndex(), "index in the canvas of the parent")
+	assert.Equal(t, 4, r.Index(0, 0))
+	assert.Equal(t, -1, r.Index(2, 0))
 }
 
 func TestPutLine(t *testing.T) {
@@ -585,3 +610,40 @@ func ExampleReplaceLineBreaks() {
 	// Output:
 	// String with line-breaks of various kinds.
 }
+
+func TestSetTransparent(t *testing.T) {
+	b := blox.New().SetColumnsAndRows(7, 3).Trim()
+	b.PutText("abcdefg" + blox.LineBreak + "hijklmn" + blox.LineBreak + "opqrstu")
+	b.Move(0, 0).SetTransparent(true).PutText("X  X" + blox.LineBreak + "  Y" + blox.LineBreak + " Z  Z")
+	expect := "XbcXefg" + blox.LineBreak + "hiYklmn" + blox.LineBreak + "oZqrZtu" + blox.LineBreak
+	assert.Equal(t, expect, b.String())
+
+	b.Move(0, 0).SetTransparent(true, '.').PutLines(".......", ".. .....")
+	expect = "XbcXefg" + blox.LineBreak + "hi klmn" + blox.LineBreak + "oZqrZtu" + blox.LineBreak
+	assert.Equal(t, expect, b.String())
+
+	b.SetTransparent(false).Move(0, 0).PutLine([]rune("..."))
+	assert.Equal(t, "...Xefg", b.Strings()[0])
+}
+
+func ExampleBlox_SetTransparent() {
+	background := "................" + blox.LineBreak
+	background += "................" + blox.LineBreak
+	background += "................" + blox.LineBreak
+
+	art := " /\\    /\\" + blox.LineBreak
+	art += "/  \\  /  \\" + blox.LineBreak
+
+	b := blox.New().Trim().SetColumnsAndRows(16, 3)
+	b.PutText(background).Move(3, 1).SetTransparent(true).PutText(art).PrintCanvas()
+	// Output:
+	// ................
+	// ..../\..../\....
+	// .../..\../..\...
+}
+
+func TestPutTextBelowCanvas(t *testing.T) {
+	b := blox.New().SetColumnsAndRows(4, 2).Trim()
+	b.PutText("ab" + blox.LineBreak + "cd" + blox.LineBreak + "efgh")
+	assert.Equal(t, []string{"ab", "cd"}, b.Strings())
+}
@@ -0,0 +1,270 @@
+package blox
+
+// LineStyle selects the characters used by DrawBox, DrawHorizontalRule and
+// DrawVerticalRule.
+type LineStyle uint8
+
+const (
+	LineASCII   LineStyle = iota // +-|
+	LineLight                    // ┌─┐│└┘
+	LineHeavy                    // ┏━┓┃┗┛
+	LineDouble                   // ╔═╗║╚╝
+	LineRounded                  // ╭─╮│╰╯
+)
+
+// Directions of the arms of a box drawing character.
+const (
+	armUp = iota
+	armRight
+	armDown
+	armLeft
+)
+
+// Weight of an arm of a box drawing character.
+const (
+	weightNone uint8 = iota
+	weightLight
+	weightHeavy
+	weightDouble
+)
+
+// arms is the weight of each arm (up, right, down, left) of a box drawing
+// character.
+type arms [4]uint8
+
+func (a arms) empty() bool {
+	return a == arms{}
+}
+
+// boxDrawing describes each box drawing character by the weight of its arms
+// in the order up, right, down, left: l is light, h heavy and d double.
+var boxDrawing = map[rune]string{
+	'─': " l l", '━': " h h", '│': "l l ", '┃': "h h ",
+	'┌': " ll ", '┍': " hl ", '┎': " lh ", '┏': " hh ",
+	'┐': "  ll", '┑': "  lh", '┒': "  hl", '┓': "  hh",
+	'└': "ll  ", '┕': "lh  ", '┖': "hl  ", '┗': "hh  ",
+	'┘': "l  l", '┙': "l  h", '┚': "h  l", '┛': "h  h",
+	'├': "lll ", '┝': "lhl ", '┞': "hll ", '┟': "llh ",
+	'┠': "hlh ", '┡': "hhl ", '┢': "lhh ", '┣': "hhh ",
+	'┤': "l ll", '┥': "l lh", '┦': "h ll", '┧': "l hl",
+	'┨': "h hl", '┩': "h lh", '┪': "l hh", '┫': "h hh",
+	'┬': " lll", '┭': " llh", '┮': " hll", '┯': " hlh",
+	'┰': " lhl", '┱': " lhh", '┲': " hhl", '┳': " hhh",
+	'┴': "ll l", '┵': "ll h", '┶': "lh l", '┷': "lh h",
+	'┸': "hl l", '┹': "hl h", '┺': "hh l", '┻': "hh h",
+	'┼': "llll", '┽': "lllh", '┾': "lhll", '┿': "lhlh",
+	'╀': "hlll", '╁': "llhl", '╂': "hlhl", '╃': "hllh",
+	'╄': "hhll", '╅': "llhh", '╆': "lhhl", '╇': "hhlh",
+	'╈': "lhhh", '╉': "hlhh", '╊': "hhhl", '╋': "hhhh",
+	'═': " d d", '║': "d d ",
+	'╒': " dl ", '╓': " ld ", '╔': " dd ",
+	'╕': "  ld", '╖': "  dl", '╗': "  dd",
+	'╘': "ld  ", '╙': "dl  ", '╚': "dd  ",
+	'╛': "l  d", '╜': "d  l", '╝': "d  d",
+	'╞': "ldl ", '╟': "dld ", '╠': "ddd ",
+	'╡': "l ld", '╢': "d dl", '╣': "d dd",
+	'╤': " dld", '╥': " ldl", '╦': " ddd",
+	'╧': "ld d", '╨': "dl l", '╩': "dd d",
+	'╪': "ldld", '╫': "dldl", '╬': "dddd",
+	'╭': " ll ", '╮': "  ll", '╯': "l  l", '╰': "ll  ",
+	'╴': "   l", '╵': "l   ", '╶': " l  ", '╷': "  l ",
+	'╸': "   h", '╹': "h   ", '╺': " h  ", '╻': "  h ",
+	'╼': " h l", '╽': "l h ", '╾': " l h", '╿': "h l ",
+}
+
+var (
+	runeArms map[rune]arms // Arms of each box drawing character.
+	armsRune map[arms]rune // Box drawing character for each combination of arms.
+)
+
+func init() {
+	weights := map[byte]uint8{' ': weightNone, 'l': weightLight, 'h': weightHeavy, 'd': weightDouble}
+	runeArms = make(map[rune]arms, len(boxDrawing))
+	armsRune = make(map[arms]rune, len(boxDrawing))
+	for r, spec := range boxDrawing {
+		var a arms
+		for i := range a {
+			a[i] = weights[spec[i]]
+		}
+		runeArms[r] = a
+		if r < '╭' || r > '╰' { // Rounded corners are only drawn, never merged into.
+			armsRune[a] = r
+		}
+	}
+}
+
+// weight returns the arm weight of the line style.
+func (s LineStyle) weight() uint8 {
+	switch s {
+	case LineHeavy:
+		return weightHeavy
+	case LineDouble:
+		return weightDouble
+	}
+	return weightLight
+}
+
+// existingArms returns the arms of what is at column x, row y if it is a box
+// drawing character or one of the ASCII line characters.
+func (b *Blox) existingArms(x int, y int) arms {
+	i := b.cellIndex(x, y)
+	if i < 0 {
+		return arms{}
+	}
+	r := b.root().Canvas[i]
+	switch r {
+	case '-':
+		return arms{armRight: weightLight, armLeft: weightLight}
+	case '|':
+		return arms{armUp: weightLight, armDown: weightLight}
+	case '+':
+		return arms{weightLight, weightLight, weightLight, weightLight}
+	}
+	return runeArms[r]
+}
+
+// junction returns the character for a line with arms a in style drawn over
+// existing, merging them into a junction. Arms drawn replace the weight of
+// existing arms in the same direction.
+func junction(existing arms, a arms, style LineStyle) rune {
+	merged := existing
+	for i := range a {
+		if a[i] != weightNone {
+			merged[i] = a[i]
+		}
+	}
+	if style == LineASCII {
+		horizontal := merged[armLeft] != weightNone || merged[armRight] != weightNone
+		vertical := merged[armUp] != weightNone || merged[armDown] != weightNone
+		switch {
+		case horizontal && vertical:
+			return '+'
+		case vertical:
+			return '|'
+		}
+		return '-'
+	}
+	if existing.empty() && style == LineRounded {
+		switch a {
+		case arms{armRight: weightLight, armDown: weightLight}:
+			return '╭'
+		case arms{armLeft: weightLight, armDown: weightLight}:
+			return '╮'
+		case arms{armUp: weightLight, armLeft: weightLight}:
+			return '╯'
+		case arms{armUp: weightLight, armRight: weightLight}:
+			return '╰'
+		}
+	}
+	if r, ok := armsRune[merged]; ok {
+		return r
+	}
+	// There is no character mixing heavy and double, try light instead of
+	// heavy before giving up and using the weight of the line drawn for all
+	// arms.
+	lighter := merged
+	for i := range lighter {
+		if lighter[i] == weightHeavy {
+			lighter[i] = weightLight
+		}
+	}
+	if r, ok := armsRune[lighter]; ok {
+		return r
+	}
+	for i := range merged {
+		if merged[i] != weightNone {
+			merged[i] = style.weight()
+		}
+	}
+	if r, ok := armsRune[merged]; ok {
+		return r
+	}
+	return armsRune[a]
+}
+
+// drawArms draws a line segment with arms a at column x, row y merging it with
+// any line already in the cell.
+func (b *Blox) drawArms(x int, y int, a arms, style LineStyle) {
+	if !b.fit(x, y) {
+		b.offCanvas("line at column %d, row %d", x, y)
+		return
+	}
+	b.setCell(x, y, junction(b.existingArms(x, y), a, style))
+}
+
+// DrawHorizontalRule draws a horizontal line length cells long starting at
+// column x, row y in line style (ASCII, light, heavy, double or rounded).
+// Where the line meets or crosses other lines the correct junction character
+// is drawn (┼ ├ ┬ ╋ ╬ etc), an end of the line that meets another line only
+// connects to it (├ rather than ┼). The cursor is not moved.
+func (b *Blox) DrawHorizontalRule(x int, y int, length int, style LineStyle) *Blox {
+	w := style.weight()
+	for i := 0; i < length; i++ {
+		a := arms{armLeft: w, armRight: w}
+		if length > 1 && !b.existingArms(x+i, y).empty() {
+			if i == 0 {
+				a[armLeft] = weightNone
+			} else if i == length-1 {
+				a[armRight] = weightNone
+			}
+		}
+		b.drawArms(x+i, y, a, style)
+	}
+	return b
+}
+
+// DrawVerticalRule draws a vertical line length cells long starting at column
+// x, row y downwards in line style, merging junctions like
+// DrawHorizontalRule. The cursor is not moved.
+func (b *Blox) DrawVerticalRule(x int, y int, length int, style LineStyle) *Blox {
+	w := style.weight()
+	for i := 0; i < length; i++ {
+		a := arms{armUp: w, armDown: w}
+		if length > 1 && !b.existingArms(x, y+i).empty() {
+			if i == 0 {
+				a[armUp] = weightNone
+			} else if i == length-1 {
+				a[armDown] = weightNone
+			}
+		}
+		b.drawArms(x, y+i, a, style)
+	}
+	return b
+}
+
+// DrawBox draws a rectangle w columns wide and h rows high with the upper left
+// hand corner at column x, row y in line style. Lines already on the canvas
+// are merged with the box into junctions, so boxes sharing an edge or lines
+// crossing the box connect properly. The cursor is not moved.
+//
+//	b.DrawBox(0, 0, 8, 3, blox.LineLight).DrawBox(7, 0, 5, 3, blox.LineLight)
+//
+//	┌──────┬───┐
+//	│      │   │
+//	└──────┴───┘
+func (b *Blox) DrawBox(x int, y int, w int, h int, style LineStyle) *Blox {
+	switch {
+	case w < 1, h < 1:
+		return b
+	case h == 1:
+		return b.DrawHorizontalRule(x, y, w, style)
+	case w == 1:
+		return b.DrawVerticalRule(x, y, h, style)
+	}
+	weight := style.weight()
+	right := x + w - 1
+	bottom := y + h - 1
+	b.drawArms(x, y, arms{armRight: weight, armDown: weight}, style)
+	b.drawArms(right, y, arms{armLeft: weight, armDown: weight}, style)
+	b.drawArms(x, bottom, arms{armUp: weight, armRight: weight}, style)
+	b.drawArms(right, bottom, arms{armUp: weight, armLeft: weight}, style)
+	for i := x + 1; i < right; i++ {
+		b.drawArms(i, y, arms{armLeft: weight, armRight: weight}, style)
+		b.drawArms(i, bottom, arms{armLeft: weight, armRight: weight}, style)
+	}
+	for i := y + 1; i < bottom; i++ {
+		b.drawArms(x, i, arms{armUp: weight, armDown: weight}, style)
+		b.drawArms(right, i, arms{armUp: weight, armDown: weight}, style)
+	}
+	return b
+}
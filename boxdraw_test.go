@@ -0,0 +1,64 @@
+package blox_test
+
+import (
+	"testing"
+
+	"github.com/sa6mwa/blox"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDrawBox(t *testing.T) {
+	b := blox.New().SetColumnsAndRows(12, 5).Trim()
+	b.DrawBox(0, 0, 8, 3, blox.LineLight).DrawBox(7, 0, 5, 3, blox.LineLight).
+		DrawBox(3, 2, 6, 3, blox.LineLight)
+	expect := "┌──────┬───┐" + blox.LineBreak +
+		"│      │   │" + blox.LineBreak +
+		"└──┬───┴┬──┘" + blox.LineBreak +
+		"   │    │" + blox.LineBreak +
+		"   └────┘" + blox.LineBreak
+	assert.Equal(t, expect, b.String())
+	assert.Equal(t, 0, b.Cursor.X)
+	assert.Equal(t, 0, b.Cursor.Y)
+}
+
+func TestDrawBoxStyles(t *testing.T) {
+	styles := map[blox.LineStyle]string{
+		blox.LineASCII:   "+--+" + blox.LineBreak + "|  |" + blox.LineBreak + "+--+" + blox.LineBreak,
+		blox.LineHeavy:   "┏━━┓" + blox.LineBreak + "┃  ┃" + blox.LineBreak + "┗━━┛" + blox.LineBreak,
+		blox.LineDouble:  "╔══╗" + blox.LineBreak + "║  ║" + blox.LineBreak + "╚══╝" + blox.LineBreak,
+		blox.LineRounded: "╭──╮" + blox.LineBreak + "│  │" + blox.LineBreak + "╰──╯" + blox.LineBreak,
+	}
+	for style, expect := range styles {
+		b := blox.New().SetColumnsAndRows(4, 3).DrawBox(0, 0, 4, 3, style)
+		assert.Equal(t, expect, b.String(), "style %d", style)
+	}
+}
+
+func TestDrawRules(t *testing.T) {
+	b := blox.New().SetColumnsAndRows(7, 5).Trim()
+	b.DrawBox(0, 0, 7, 5, blox.LineDouble).
+		DrawHorizontalRule(0, 2, 7, blox.LineLight).
+		DrawVerticalRule(3, 0, 5, blox.LineHeavy)
+	// There are no characters mixing heavy and double, light is used instead.
+	expect := "╔══╤══╗" + blox.LineBreak +
+		"║  ┃  ║" + blox.LineBreak +
+		"╟──╂──╢" + blox.LineBreak +
+		"║  ┃  ║" + blox.LineBreak +
+		"╚══╧══╝" + blox.LineBreak
+	assert.Equal(t, expect, b.String())
+
+	b = blox.New().SetColumnsAndRows(5, 3).Trim()
+	b.DrawVerticalRule(2, 0, 3, blox.LineASCII).DrawHorizontalRule(0, 1, 5, blox.LineASCII).
+		DrawBox(-1, -1, 3, 3, blox.LineLight)
+	expect = " │|" + blox.LineBreak +
+		"─┴+--" + blox.LineBreak +
+		"  |" + blox.LineBreak
+	assert.Equal(t, expect, b.String())
+}
+
+func TestDrawBoxMixedWeights(t *testing.T) {
+	b := blox.New().SetColumnsAndRows(5, 3).Trim()
+	b.DrawBox(0, 0, 5, 3, blox.LineHeavy).DrawHorizontalRule(0, 1, 5, blox.LineDouble)
+	assert.Equal(t, "┏━━━┓", b.Strings()[0])
+	assert.Equal(t, "╞═══╡", b.Strings()[1])
+}
@@ -0,0 +1,140 @@
+package blox
+
+// BrailleBlank is the braille pattern without dots (U+2800).
+const BrailleBlank rune = 0x2800
+
+// brailleDots is the bit of each dot of a braille pattern by row (0-3) and
+// column (0-1) within the cell.
+var brailleDots = [4][2]uint8{
+	{0x01, 0x08},
+	{0x02, 0x10},
+	{0x04, 0x20},
+	{0x40, 0x80},
+}
+
+// Braille is a surface of pixels drawn with Unicode braille patterns, each
+// cell of the canvas holds 2x4 pixels (dots), which gives plots and charts 8
+// times the resolution of the canvas in plain text. Pixel 0,0 is the upper
+// left hand corner and pixels outside of the surface are ignored. Render the
+// surface onto a Blox with DrawBraille.
+//
+//	p := blox.NewBraille(10, 2).Line(0, 7, 19, 0)
+//	b.DrawBraille(p, 0, 0)
+type Braille struct {
+	columns int
+	rows    int
+	cells   []uint8 // Braille pattern of each cell, 1 bit per dot.
+}
+
+// NewBraille returns an empty braille surface covering columns x rows cells of
+// the canvas, 2*columns pixels wide and 4*rows pixels high.
+func NewBraille(columns int, rows int) *Braille {
+	columns, rows = maxInt(columns, 0), maxInt(rows, 0)
+	return &Braille{
+		columns: columns,
+		rows:    rows,
+		cells:   make([]uint8, columns*rows),
+	}
+}
+
+// Size returns the width and height of the surface in pixels.
+func (p *Braille) Size() (width int, height int) {
+	return p.columns * 2, p.rows * 4
+}
+
+// dot returns the index of the cell and the bit of pixel x, y or -1 if it is
+// outside of the surface.
+func (p *Braille) dot(x int, y int) (int, uint8) {
+	if x < 0 || y < 0 || x >= p.columns*2 || y >= p.rows*4 {
+		return -1, 0
+	}
+	return y/4*p.columns + x/2, brailleDots[y%4][x%2]
+}
+
+// SetPixel sets (draws) pixel x, y.
+func (p *Braille) SetPixel(x int, y int) *Braille {
+	if i, bit := p.dot(x, y); i >= 0 {
+		p.cells[i] |= bit
+	}
+	return p
+}
+
+// ClearPixel clears (erases) pixel x, y.
+func (p *Braille) ClearPixel(x int, y int) *Braille {
+	if i, bit := p.dot(x, y); i >= 0 {
+		p.cells[i] &^= bit
+	}
+	return p
+}
+
+// Pixel returns true if pixel x, y is set.
+func (p *Braille) Pixel(x int, y int) bool {
+	i, bit := p.dot(x, y)
+	return i >= 0 && p.cells[i]&bit != 0
+}
+
+// Clear clears all pixels.
+func (p *Braille) Clear() *Braille {
+	for i := range p.cells {
+		p.cells[i] = 0
+	}
+	return p
+}
+
+// Line sets the pixels of a straight line from x0, y0 to x1, y1.
+func (p *Braille) Line(x0 int, y0 int, x1 int, y1 int) *Braille {
+	for _, pt := range linePoints(x0, y0, x1, y1) {
+		p.SetPixel(pt.X, pt.Y)
+	}
+	return p
+}
+
+// Circle sets the pixels of a circle centered at x, y with radius r. Braille
+// dots are roughly as far apart horizontally as vertically, so the circle
+// looks round.
+func (p *Braille) Circle(x int, y int, r int) *Braille {
+	if r < 0 {
+		return p
+	}
+	// Midpoint circle algorithm, plotting one octant mirrored eight ways.
+	dx, dy, e := r, 0, 1-r
+	for dx >= dy {
+		p.SetPixel(x+dx, y+dy).SetPixel(x-dx, y+dy).SetPixel(x+dx, y-dy).SetPixel(x-dx, y-dy)
+		p.SetPixel(x+dy, y+dx).SetPixel(x-dy, y+dx).SetPixel(x+dy, y-dx).SetPixel(x-dy, y-dx)
+		dy++
+		if e < 0 {
+			e += 2*dy + 1
+		} else {
+			dx--
+			e += 2*(dy-dx) + 1
+		}
+	}
+	return p
+}
+
+// Rune returns the braille pattern of cell column, row of the surface or
+// BrailleBlank if it is outside of the surface.
+func (p *Braille) Rune(column int, row int) rune {
+	if column < 0 || row < 0 || column >= p.columns || row >= p.rows {
+		return BrailleBlank
+	}
+	return BrailleBlank + rune(p.cells[row*p.columns+column])
+}
+
+// DrawBraille renders the braille surface p onto the canvas with the upper left
+// hand corner at column x, row y in the current pen. Cells without any dots
+// are written as spaces, so they are skipped in transparent mode (see
+// SetTransparent) and trimmed like any other space. Everything outside of the
+// canvas is clipped and the cursor is not moved.
+func (b *Blox) DrawBraille(p *Braille, x int, y int) *Blox {
+	for row := 0; row < p.rows; row++ {
+		for column := 0; column < p.columns; column++ {
+			r := p.Rune(column, row)
+			if r == BrailleBlank {
+				r = ' '
+			}
+			b.plot(x+column, y+row, r)
+		}
+	}
+	return b
+}
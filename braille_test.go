@@ -0,0 +1,57 @@
+package blox_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/sa6mwa/blox"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBraille(t *testing.T) {
+	p := blox.NewBraille(2, 1)
+	w, h := p.Size()
+	assert.Equal(t, 4, w)
+	assert.Equal(t, 4, h)
+	assert.Equal(t, blox.BrailleBlank, p.Rune(0, 0))
+
+	p.SetPixel(0, 0).SetPixel(1, 3).SetPixel(2, 1).SetPixel(-1, 0).SetPixel(4, 0)
+	assert.True(t, p.Pixel(1, 3))
+	assert.False(t, p.Pixel(1, 2))
+	assert.False(t, p.Pixel(4, 0))
+	assert.Equal(t, '⢁', p.Rune(0, 0))
+	assert.Equal(t, '⠂', p.Rune(1, 0))
+	assert.Equal(t, blox.BrailleBlank, p.Rune(2, 0))
+
+	p.ClearPixel(0, 0)
+	assert.Equal(t, '⢀', p.Rune(0, 0))
+	p.Clear()
+	assert.Equal(t, blox.BrailleBlank, p.Rune(0, 0))
+
+	p = blox.NewBraille(4, 1).Line(0, 0, 7, 3)
+	b := blox.New().SetColumnsAndRows(6, 2).Trim().Move(1, 1)
+	b.DrawBraille(p, 1, 0)
+	assert.Equal(t, []string{" ⠉⠒⠤⣀"}, b.Strings())
+	assert.Equal(t, 1, b.Cursor.X, "the cursor is not moved")
+	assert.Equal(t, 1, b.Cursor.Y)
+
+	b = blox.New().SetColumnsAndRows(6, 1).Trim().PutText("abcdef").SetTransparent(true)
+	b.DrawBraille(blox.NewBraille(4, 1).SetPixel(2, 0), 4, 0)
+	assert.Equal(t, []string{"abcde⠁"}, b.Strings(), "clipped and transparent")
+
+	p = blox.NewBraille(3, 2).Circle(2, 3, 2)
+	b = blox.New().SetColumnsAndRows(3, 2).Trim().DrawBraille(p, 0, 0)
+	assert.Equal(t, []string{"⡔⠒⡄", "⠑⠒⠁"}, b.Strings())
+}
+
+func ExampleBlox_DrawBraille() {
+	p := blox.NewBraille(10, 2)
+	for x := 0; x < 20; x++ {
+		p.SetPixel(x, 7-x*x/50)
+	}
+	b := blox.New().SetColumnsAndRows(12, 2).Trim().DrawBox(0, 0, 1, 2, blox.LineLight)
+	fmt.Println(b.DrawBraille(p, 1, 0).String())
+	// Output:
+	// │       ⢀⠤⠊
+	// │⣀⣀⣀⣀⠤⠒⠊⠁
+}
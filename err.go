@@ -0,0 +1,54 @@
+package blox
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrOffCanvas is recorded in strict mode (see SetStrict) when something is
+// written outside of the canvas.
+var ErrOffCanvas = errors.New("write outside of canvas")
+
+// Err returns the first error recorded since New or ClearErr, or nil. Errors
+// are sticky: chainable functions can not return an error, so they record it
+// and carry on, check Err at the end of the chain:
+//
+//	if err := b.PutText(report).FprintCanvas(w).Err(); err != nil {
+//	  return err
+//	}
+//
+// Output errors from FprintCanvas, PrintCanvas and friends are always recorded,
+// writes outside of the canvas only in strict mode. A Region shares the error
+// of the Blox it was created from.
+func (b *Blox) Err() error {
+	return b.root().err
+}
+
+// ClearErr forgets the recorded error (see Err).
+func (b *Blox) ClearErr() *Blox {
+	b.root().err = nil
+	return b
+}
+
+// SetStrict enables or disables strict mode. In strict mode writing outside of
+// the canvas, for example text running past the bottom or the right edge,
+// records an error wrapping ErrOffCanvas (see Err). Without strict mode
+// off-canvas content is silently clipped.
+func (b *Blox) SetStrict(strict bool) *Blox {
+	b.Strict = strict
+	return b
+}
+
+// setErr records err unless an error has already been recorded.
+func (b *Blox) setErr(err error) {
+	if s := b.root(); s.err == nil {
+		s.err = err
+	}
+}
+
+// offCanvas records an ErrOffCanvas for what was written in strict mode.
+func (b *Blox) offCanvas(format string, a ...any) {
+	if b.Strict {
+		b.setErr(fmt.Errorf("%w: "+format, append([]any{ErrOffCanvas}, a...)...))
+	}
+}
@@ -0,0 +1,64 @@
+package blox_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/sa6mwa/blox"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrStrict(t *testing.T) {
+	b := blox.New().SetColumnsAndRows(5, 2)
+	b.PutText("123456" + blox.LineBreak + "a" + blox.LineBreak + "b")
+	assert.NoError(t, b.Err(), "off-canvas writes are only errors in strict mode")
+
+	b = blox.New().SetColumnsAndRows(5, 2).SetStrict(true)
+	b.PutText("12345" + blox.LineBreak + "a")
+	b.DrawSeparator().Move(0, 0).DrawSplit()
+	assert.NoError(t, b.Err())
+
+	b.Move(0, 0).PutText("123456")
+	assert.ErrorIs(t, b.Err(), blox.ErrOffCanvas)
+	assert.EqualError(t, b.Err(), `write outside of canvas: "6"`)
+
+	b.Move(0, 1).PutText("a" + blox.LineBreak + "b")
+	assert.EqualError(t, b.Err(), `write outside of canvas: "6"`, "the first error is kept")
+	assert.NoError(t, b.ClearErr().Err())
+
+	r := b.Region(3, 0, 5, 2)
+	r.PutText("xyz")
+	assert.EqualError(t, b.Err(), `write outside of canvas: 'z' at column 2, row 0`)
+	assert.Equal(t, b.Err(), r.Err())
+
+	b.ClearErr().DrawBox(3, 0, 3, 2, blox.LineLight)
+	assert.ErrorIs(t, b.Err(), blox.ErrOffCanvas)
+}
+
+func TestErrOutput(t *testing.T) {
+	r, w, err := os.Pipe()
+	if !assert.NoError(t, err) {
+		return
+	}
+	r.Close()
+	w.Close()
+	b := blox.New().SetColumnsAndRows(5, 1).PutText("hello")
+	assert.Error(t, b.WriteCanvas(w))
+	assert.NoError(t, b.Err())
+	assert.Error(t, b.WriteANSI(w, blox.ProfileNone))
+	assert.NoError(t, b.Err())
+	assert.Error(t, b.FprintCanvas(w).Err())
+	b.ClearErr()
+	assert.Error(t, b.FprintANSI(w, blox.Profile256).Err())
+}
+
+func ExampleBlox_Err() {
+	b := blox.New().SetColumnsAndRows(10, 1).SetStrict(true)
+	if err := b.PutText("Hello world").Err(); errors.Is(err, blox.ErrOffCanvas) {
+		fmt.Println(err)
+	}
+	// Output:
+	// write outside of canvas: "d"
+}
@@ -0,0 +1,266 @@
+package blox
+
+import "strings"
+
+// Size of a glyph of the built-in bitmap font in font pixels: 5 columns and
+// 11 rows, 2 rows for accents above capitals, 7 rows from the cap height to
+// the baseline and 2 rows for descenders. A cell is one column and one row
+// larger than the glyph.
+const (
+	fontColumns     = 5
+	fontRows        = 11
+	fontCellColumns = fontColumns + 1
+	fontCellRows    = fontRows + 1
+)
+
+// glyph is a character of the bitmap font, one row per element where bit 4 is
+// the leftmost column.
+type glyph [fontRows]uint8
+
+// fontGlyphs is the built-in bitmap font (ASCII and the symbols of Latin-1),
+// rows separated by |, # is a lit pixel. Each glyph is 7 rows from the cap
+// height to the baseline, optionally followed by 2 descender rows. Latin-1
+// letters with accents are composed from fontAccents.
+var fontGlyphs = map[rune]string{
+	' ':      "",
+	'!':      "..#..|..#..|..#..|..#..|..#..|.....|..#..",
+	'"':      ".#.#.|.#.#.|.#.#.",
+	'#':      ".#.#.|.#.#.|#####|.#.#.|#####|.#.#.|.#.#.",
+	'$':      "..#..|.####|#.#..|.###.|..#.#|####.|..#..",
+	'%':      "##...|##..#|...#.|..#..|.#...|#..##|...##",
+	'&':      ".##..|#..#.|#.#..|.#...|#.#.#|#..#.|.##.#",
+	'\'':     "..#..|..#..|.#...",
+	'(':      "...#.|..#..|.#...|.#...|.#...|..#..|...#.",
+	')':      ".#...|..#..|...#.|...#.|...#.|..#..|.#...",
+	'*':      ".....|..#..|#.#.#|.###.|#.#.#|..#..",
+	'+':      ".....|..#..|..#..|#####|..#..|..#..",
+	',':      ".....|.....|.....|.....|.....|.##..|..#..|.#...",
+	'-':      ".....|.....|.....|#####",
+	'.':      ".....|.....|.....|.....|.....|.##..|.##..",
+	'/':      ".....|....#|...#.|..#..|.#...|#....",
+	'0':      ".###.|#...#|#..##|#.#.#|##..#|#...#|.###.",
+	'1':      "..#..|.##..|..#..|..#..|..#..|..#..|.###.",
+	'2':      ".###.|#...#|....#|...#.|..#..|.#...|#####",
+	'3':      "#####|...#.|..#..|...#.|....#|#...#|.###.",
+	'4':      "...#.|..##.|.#.#.|#..#.|#####|...#.|...#.",
+	'5':      "#####|#....|####.|....#|....#|#...#|.###.",
+	'6':      "..##.|.#...|#....|####.|#...#|#...#|.###.",
+	'7':      "#####|....#|...#.|..#..|.#...|.#...|.#...",
+	'8':      ".###.|#...#|#...#|.###.|#...#|#...#|.###.",
+	'9':      ".###.|#...#|#...#|.####|....#|...#.|.##..",
+	':':      ".....|.##..|.##..|.....|.##..|.##..",
+	';':      ".....|.##..|.##..|.....|.##..|..#..|.#...",
+	'<':      "...#.|..#..|.#...|#....|.#...|..#..|...#.",
+	'=':      ".....|.....|#####|.....|#####",
+	'>':      ".#...|..#..|...#.|....#|...#.|..#..|.#...",
+	'?':      ".###.|#...#|....#|...#.|..#..|.....|..#..",
+	'@':      ".###.|#...#|....#|.##.#|#.#.#|#.#.#|.###.",
+	'A':      ".###.|#...#|#...#|#...#|#####|#...#|#...#",
+	'B':      "####.|#...#|#...#|####.|#...#|#...#|####.",
+	'C':      ".###.|#...#|#....|#....|#....|#...#|.###.",
+	'D':      "###..|#..#.|#...#|#...#|#...#|#..#.|###..",
+	'E':      "#####|#....|#....|####.|#....|#....|#####",
+	'F':      "#####|#....|#....|####.|#....|#....|#....",
+	'G':      ".###.|#...#|#....|#.###|#...#|#...#|.####",
+	'H':      "#...#|#...#|#...#|#####|#...#|#...#|#...#",
+	'I':      ".###.|..#..|..#..|..#..|..#..|..#..|.###.",
+	'J':      "..###|...#.|...#.|...#.|...#.|#..#.|.##..",
+	'K':      "#...#|#..#.|#.#..|##...|#.#..|#..#.|#...#",
+	'L':      "#....|#....|#....|#....|#....|#....|#####",
+	'M':      "#...#|##.##|#.#.#|#.#.#|#...#|#...#|#...#",
+	'N':      "#...#|#...#|##..#|#.#.#|#..##|#...#|#...#",
+	'O':      ".###.|#...#|#...#|#...#|#...#|#...#|.###.",
+	'P':      "####.|#...#|#...#|####.|#....|#....|#....",
+	'Q':      ".###.|#...#|#...#|#...#|#.#.#|#..#.|.##.#",
+	'R':      "####.|#...#|#...#|####.|#.#..|#..#.|#...#",
+	'S':      ".####|#....|#....|.###.|....#|....#|####.",
+	'T':      "#####|..#..|..#..|..#..|..#..|..#..|..#..",
+	'U':      "#...#|#...#|#...#|#...#|#...#|#...#|.###.",
+	'V':      "#...#|#...#|#...#|#...#|#...#|.#.#.|..#..",
+	'W':      "#...#|#...#|#...#|#.#.#|#.#.#|#.#.#|.#.#.",
+	'X':      "#...#|#...#|.#.#.|..#..|.#.#.|#...#|#...#",
+	'Y':      "#...#|#...#|#...#|.#.#.|..#..|..#..|..#..",
+	'Z':      "#####|....#|...#.|..#..|.#...|#....|#####",
+	'[':      ".###.|.#...|.#...|.#...|.#...|.#...|.###.",
+	'\\':     ".....|#....|.#...|..#..|...#.|....#",
+	']':      ".###.|...#.|...#.|...#.|...#.|...#.|.###.",
+	'^':      "..#..|.#.#.|#...#",
+	'_':      ".....|.....|.....|.....|.....|.....|.....|#####",
+	'`':      ".#...|..#..|...#.",
+	'a':      ".....|.....|.###.|....#|.####|#...#|.####",
+	'b':      "#....|#....|#.##.|##..#|#...#|#...#|####.",
+	'c':      ".....|.....|.###.|#....|#....|#...#|.###.",
+	'd':      "....#|....#|.##.#|#..##|#...#|#...#|.####",
+	'e':      ".....|.....|.###.|#...#|#####|#....|.###.",
+	'f':      "..##.|.#..#|.#...|###..|.#...|.#...|.#...",
+	'g':      ".....|.....|.####|#...#|#...#|#...#|.####|....#|.###.",
+	'h':      "#....|#....|#.##.|##..#|#...#|#...#|#...#",
+	'i':      "..#..|.....|.##..|..#..|..#..|..#..|.###.",
+	'j':      "...#.|.....|..##.|...#.|...#.|...#.|...#.|#..#.|.##..",
+	'k':      "#....|#....|#..#.|#.#..|##...|#.#..|#..#.",
+	'l':      ".##..|..#..|..#..|..#..|..#..|..#..|.###.",
+	'm':      ".....|.....|##.#.|#.#.#|#.#.#|#...#|#...#",
+	'n':      ".....|.....|#.##.|##..#|#...#|#...#|#...#",
+	'o':      ".....|.....|.###.|#...#|#...#|#...#|.###.",
+	'p':      ".....|.....|####.|#...#|#...#|#...#|####.|#....|#....",
+	'q':      ".....|.....|.####|#...#|#...#|#...#|.####|....#|....#",
+	'r':      ".....|.....|#.##.|##..#|#....|#....|#....",
+	's':      ".....|.....|.####|#....|.###.|....#|####.",
+	't':      ".#...|.#...|###..|.#...|.#...|.#..#|..##.",
+	'u':      ".....|.....|#...#|#...#|#...#|#..##|.##.#",
+	'v':      ".....|.....|#...#|#...#|#...#|.#.#.|..#..",
+	'w':      ".....|.....|#...#|#...#|#.#.#|#.#.#|.#.#.",
+	'x':      ".....|.....|#...#|.#.#.|..#..|.#.#.|#...#",
+	'y':      ".....|.....|#...#|#...#|#...#|#...#|.####|....#|.###.",
+	'z':      ".....|.....|#####|...#.|..#..|.#...|#####",
+	'{':      "...#.|..#..|..#..|.#...|..#..|..#..|...#.",
+	'|':      "..#..|..#..|..#..|..#..|..#..|..#..|..#..",
+	'}':      ".#...|..#..|..#..|...#.|..#..|..#..|.#...",
+	'~':      ".....|.....|.#...|#.#.#|...#.",
+	'\u00a0': "",
+	'¡':      "..#..|.....|..#..|..#..|..#..|..#..|..#..",
+	'¢':      "..#..|.###.|#.#..|#.#..|#.#.#|.###.|..#..",
+	'£':      "..##.|.#..#|.#...|###..|.#...|.#..#|#.##.",
+	'¤':      ".....|#...#|.###.|.#.#.|.###.|#...#",
+	'¥':      "#...#|.#.#.|..#..|#####|..#..|#####|..#..",
+	'¦':      "..#..|..#..|..#..|.....|..#..|..#..|..#..",
+	'§':      ".###.|#....|.##..|.#.#.|..##.|....#|.###.",
+	'¨':      ".#.#.",
+	'©':      ".###.|#...#|#.##.|#.#..|#.##.|#...#|.###.",
+	'ª':      ".###.|....#|.####|#...#|.####|.....|#####",
+	'«':      ".....|..#.#|.#.#.|#.#..|.#.#.|..#.#",
+	'¬':      ".....|.....|#####|....#|....#",
+	'\u00ad': ".....|.....|.....|.###.",
+	'®':      ".###.|#...#|###.#|#.#.#|##..#|#...#|.###.",
+	'¯':      "#####",
+	'°':      ".##..|#..#.|#..#.|.##..",
+	'±':      "..#..|..#..|#####|..#..|..#..|.....|#####",
+	'²':      ".##..|...#.|..#..|.###.",
+	'³':      ".##..|..##.|...#.|.##..",
+	'´':      "...#.|..#..",
+	'µ':      ".....|.....|#...#|#...#|#...#|##..#|#.##.|#....|#....",
+	'¶':      ".####|###.#|###.#|.##.#|..#.#|..#.#|..#.#",
+	'·':      ".....|.....|.....|..#..",
+	'¸':      ".....|.....|.....|.....|.....|.....|.....|..#..|.#...",
+	'¹':      "..#..|.##..|..#..|.###.",
+	'º':      ".###.|#...#|#...#|.###.|.....|#####",
+	'»':      ".....|#.#..|.#.#.|..#.#|.#.#.|#.#..",
+	'¼':      "#....|#...#|#..#.|..#..|.#.#.|#.##.|...#.",
+	'½':      "#....|#...#|#..#.|..#..|.#.##|#...#|...##",
+	'¾':      "##...|.#..#|##.#.|..#..|.#.#.|#.##.|...#.",
+	'¿':      "..#..|.....|..#..|.#...|#....|#...#|.###.",
+	'Æ':      ".####|#.#..|#.#..|#####|#.#..|#.#..|#.###",
+	'Ð':      "###..|#..#.|#...#|###.#|#...#|#..#.|###..",
+	'×':      ".....|#...#|.#.#.|..#..|.#.#.|#...#",
+	'Ø':      ".###.|#..##|#.#.#|#.#.#|#.#.#|##..#|.###.",
+	'Þ':      "#....|####.|#...#|#...#|####.|#....|#....",
+	'ß':      ".##..|#..#.|#..#.|#.##.|#...#|#...#|#.##.",
+	'æ':      ".....|.....|##.#.|..#.#|.####|#.#..|.####",
+	'ð':      ".#.#.|..#..|.#.#.|....#|.####|#...#|.###.",
+	'÷':      ".....|..#..|.....|#####|.....|..#..",
+	'ø':      ".....|.....|.###.|#..##|#.#.#|##..#|.###.",
+	'þ':      ".....|#....|####.|#...#|#...#|#...#|####.|#....|#....",
+	'ı':      ".....|.....|.##..|..#..|..#..|..#..|.###.",
+}
+
+// fontAccent is a combining mark drawn in the 2 rows above the letter it is
+// attached to, or in the descender rows if below.
+type fontAccent struct {
+	rows  [2]uint8
+	below bool
+}
+
+// fontAccents are the combining marks the built-in font can draw.
+var fontAccents = map[rune]fontAccent{
+	0x0300: {rows: [2]uint8{0b01000, 0b00100}},              // Grave.
+	0x0301: {rows: [2]uint8{0b00010, 0b00100}},              // Acute.
+	0x0302: {rows: [2]uint8{0b00100, 0b01010}},              // Circumflex.
+	0x0303: {rows: [2]uint8{0b01101, 0b10110}},              // Tilde.
+	0x0308: {rows: [2]uint8{0b01010, 0b00000}},              // Diaeresis.
+	0x030a: {rows: [2]uint8{0b01110, 0b01010}},              // Ring above.
+	0x0327: {rows: [2]uint8{0b00100, 0b01000}, below: true}, // Cedilla.
+}
+
+// fontComposed are the Latin-1 letters with accents as a letter and a
+// combining mark, the dotless i is used for i with accents.
+var fontComposed = map[rune][2]rune{}
+
+func init() {
+	letters := []struct {
+		base    rune
+		letters string
+		accents []rune
+	}{
+		{'A', "ÀÁÂÃÄÅ", []rune{0x300, 0x301, 0x302, 0x303, 0x308, 0x30a}},
+		{'a', "àáâãäå", []rune{0x300, 0x301, 0x302, 0x303, 0x308, 0x30a}},
+		{'E', "ÈÉÊË", []rune{0x300, 0x301, 0x302, 0x308}},
+		{'e', "èéêë", []rune{0x300, 0x301, 0x302, 0x308}},
+		{'I', "ÌÍÎÏ", []rune{0x300, 0x301, 0x302, 0x308}},
+		{'ı', "ìíîï", []rune{0x300, 0x301, 0x302, 0x308}},
+		{'O', "ÒÓÔÕÖ", []rune{0x300, 0x301, 0x302, 0x303, 0x308}},
+		{'o', "òóôõö", []rune{0x300, 0x301, 0x302, 0x303, 0x308}},
+		{'U', "ÙÚÛÜ", []rune{0x300, 0x301, 0x302, 0x308}},
+		{'u', "ùúûü", []rune{0x300, 0x301, 0x302, 0x308}},
+		{'N', "Ñ", []rune{0x303}},
+		{'n', "ñ", []rune{0x303}},
+		{'C', "Ç", []rune{0x327}},
+		{'c', "ç", []rune{0x327}},
+		{'Y', "Ý", []rune{0x301}},
+		{'y', "ýÿ", []rune{0x301, 0x308}},
+	}
+	for _, l := range letters {
+		for i, r := range []rune(l.letters) {
+			fontComposed[r] = [2]rune{l.base, l.accents[i]}
+		}
+	}
+}
+
+// fontBitmaps holds the parsed glyphs of fontGlyphs.
+var fontBitmaps = func() map[rune]glyph {
+	bitmaps := make(map[rune]glyph, len(fontGlyphs))
+	for r, spec := range fontGlyphs {
+		var g glyph
+		if spec != "" {
+			for i, row := range strings.Split(spec, "|") {
+				for j, c := range row {
+					if c == '#' {
+						g[i+2] |= 1 << (fontColumns - 1 - j)
+					}
+				}
+			}
+		}
+		bitmaps[r] = g
+	}
+	return bitmaps
+}()
+
+// fontGlyph returns the glyph of the grapheme cluster r followed by marks and
+// false if the font has no glyph for r. Known combining marks are drawn above
+// (or below) the letter, others are ignored.
+func fontGlyph(r rune, marks []rune) (glyph, bool) {
+	if c, ok := fontComposed[r]; ok {
+		r, marks = c[0], append([]rune{c[1]}, marks...)
+	}
+	g, ok := fontBitmaps[r]
+	if !ok {
+		return g, false
+	}
+	top := 2
+	for top < fontRows && g[top] == 0 {
+		top++
+	}
+	for _, m := range marks {
+		accent, ok := fontAccents[m]
+		switch {
+		case !ok:
+		case accent.below:
+			g[fontRows-2] |= accent.rows[0]
+			g[fontRows-1] |= accent.rows[1]
+		case top >= 2:
+			g[top-2] |= accent.rows[0]
+			g[top-1] |= accent.rows[1]
+			top -= 2
+		}
+	}
+	return g, true
+}
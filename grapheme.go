@@ -0,0 +1,236 @@
+package blox
+
+import "unicode"
+
+// Grapheme cluster break property values from Unicode Standard Annex #29,
+// Unicode Text Segmentation.
+type graphemeBreak uint8
+
+const (
+	gbOther graphemeBreak = iota
+	gbCR
+	gbLF
+	gbControl
+	gbExtend
+	gbZWJ
+	gbRegionalIndicator
+	gbPrepend
+	gbSpacingMark
+	gbL
+	gbV
+	gbT
+	gbLV
+	gbLVT
+)
+
+// prependRanges have the Grapheme_Cluster_Break property Prepend.
+var prependRanges = []runeRange{
+	{0x0600, 0x0605}, {0x06DD, 0x06DD}, {0x070F, 0x070F}, {0x0890, 0x0891},
+	{0x08E2, 0x08E2}, {0x0D4E, 0x0D4E}, {0x110BD, 0x110BD}, {0x110CD, 0x110CD},
+	{0x111C2, 0x111C3}, {0x1193F, 0x1193F}, {0x11941, 0x11941}, {0x11A3A, 0x11A3A},
+	{0x11A84, 0x11A89}, {0x11D46, 0x11D46}, {0x11F02, 0x11F02},
+}
+
+// extendedPictographicRanges have the Extended_Pictographic property (emoji
+// and other pictographs), emoji modifiers (skin tones) excluded.
+var extendedPictographicRanges = []runeRange{
+	{0x00A9, 0x00A9}, {0x00AE, 0x00AE}, {0x203C, 0x203C}, {0x2049, 0x2049},
+	{0x2122, 0x2122}, {0x2139, 0x2139}, {0x2194, 0x2199}, {0x21A9, 0x21AA},
+	{0x231A, 0x231B}, {0x2328, 0x2328}, {0x2388, 0x2388}, {0x23CF, 0x23CF},
+	{0x23E9, 0x23F3}, {0x23F8, 0x23FA}, {0x24C2, 0x24C2}, {0x25AA, 0x25AB},
+	{0x25B6, 0x25B6}, {0x25C0, 0x25C0}, {0x25FB, 0x25FE}, {0x2600, 0x2605},
+	{0x2607, 0x2612}, {0x2614, 0x2685}, {0x2690, 0x2705}, {0x2708, 0x2712},
+	{0x2714, 0x2714}, {0x2716, 0x2716}, {0x271D, 0x271D}, {0x2721, 0x2721},
+	{0x2728, 0x2728}, {0x2733, 0x2734}, {0x2744, 0x2744}, {0x2747, 0x2747},
+	{0x274C, 0x274C}, {0x274E, 0x274E}, {0x2753, 0x2755}, {0x2757, 0x2757},
+	{0x2763, 0x2767}, {0x2795, 0x2797}, {0x27A1, 0x27A1}, {0x27B0, 0x27B0},
+	{0x27BF, 0x27BF}, {0x2934, 0x2935}, {0x2B05, 0x2B07}, {0x2B1B, 0x2B1C},
+	{0x2B50, 0x2B50}, {0x2B55, 0x2B55}, {0x3030, 0x3030}, {0x303D, 0x303D},
+	{0x3297, 0x3297}, {0x3299, 0x3299}, {0x1F000, 0x1F0FF}, {0x1F10D, 0x1F10F},
+	{0x1F12F, 0x1F12F}, {0x1F16C, 0x1F171}, {0x1F17E, 0x1F17F}, {0x1F18E, 0x1F18E},
+	{0x1F191, 0x1F19A}, {0x1F1AD, 0x1F1E5}, {0x1F201, 0x1F20F}, {0x1F21A, 0x1F21A},
+	{0x1F22F, 0x1F22F}, {0x1F232, 0x1F23A}, {0x1F23C, 0x1F23F}, {0x1F249, 0x1F3FA},
+	{0x1F400, 0x1F53D}, {0x1F546, 0x1F64F}, {0x1F680, 0x1F6FF}, {0x1F774, 0x1F77F},
+	{0x1F7D5, 0x1F7FF}, {0x1F80C, 0x1F80F}, {0x1F848, 0x1F84F}, {0x1F85A, 0x1F85F},
+	{0x1F888, 0x1F88F}, {0x1F8AE, 0x1F8FF}, {0x1F90C, 0x1F93A}, {0x1F93C, 0x1F945},
+	{0x1F947, 0x1FAFF}, {0x1FC00, 0x1FFFD},
+}
+
+// spacingMarkExceptions are spacing combining marks (Mc) that do not have the
+// Grapheme_Cluster_Break property SpacingMark.
+var spacingMarkExceptions = []runeRange{
+	{0x102B, 0x102C}, {0x1038, 0x1038}, {0x1062, 0x1064}, {0x1067, 0x106D},
+	{0x1083, 0x1083}, {0x1087, 0x108C}, {0x108F, 0x108F}, {0x109A, 0x109C},
+	{0x1A61, 0x1A61}, {0x1A63, 0x1A64}, {0xAA7B, 0xAA7B}, {0xAA7D, 0xAA7D},
+	{0x11720, 0x11721},
+}
+
+func isExtendedPictographic(r rune) bool {
+	return r >= 0xA9 && inRanges(r, extendedPictographicRanges)
+}
+
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1F1E6 && r <= 0x1F1FF
+}
+
+// graphemeBreakProperty returns the Grapheme_Cluster_Break property of r.
+func graphemeBreakProperty(r rune) graphemeBreak {
+	switch {
+	case r == '\r':
+		return gbCR
+	case r == '\n':
+		return gbLF
+	case r < 0x20, r >= 0x7f && r < 0xa0:
+		return gbControl
+	case r < 0x0300:
+		return gbOther
+	case r == 0x200D:
+		return gbZWJ
+	case r == 0x200C, r >= 0x1F3FB && r <= 0x1F3FF, r >= 0xE0020 && r <= 0xE007F:
+		return gbExtend
+	case isRegionalIndicator(r):
+		return gbRegionalIndicator
+	case inRanges(r, prependRanges):
+		return gbPrepend
+	case (r >= 0x1100 && r <= 0x115F) || (r >= 0xA960 && r <= 0xA97C):
+		return gbL
+	case (r >= 0x1160 && r <= 0x11A7) || (r >= 0xD7B0 && r <= 0xD7C6):
+		return gbV
+	case (r >= 0x11A8 && r <= 0x11FF) || (r >= 0xD7CB && r <= 0xD7FB):
+		return gbT
+	case r >= 0xAC00 && r <= 0xD7A3:
+		if (r-0xAC00)%28 == 0 {
+			return gbLV
+		}
+		return gbLVT
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Other_Grapheme_Extend):
+		return gbExtend
+	case r == 0x0E33, r == 0x0EB3:
+		return gbSpacingMark
+	case unicode.Is(unicode.Mc, r):
+		if inRanges(r, spacingMarkExceptions) {
+			return gbOther
+		}
+		return gbSpacingMark
+	case unicode.In(r, unicode.Zl, unicode.Zp, unicode.Cf, unicode.Cs):
+		return gbControl
+	}
+	return gbOther
+}
+
+// graphemeLength returns the number of runes in the first extended grapheme
+// cluster of runes according to the rules in UAX #29 (GB9c, Indic conjuncts,
+// is not implemented).
+func graphemeLength(runes []rune) int {
+	if len(runes) == 0 {
+		return 0
+	}
+	const (
+		noEmoji    = iota
+		emoji      // GB11: ExtPict Extend*
+		emojiJoint // GB11: ExtPict Extend* ZWJ
+	)
+	prev := graphemeBreakProperty(runes[0])
+	emojiState := noEmoji
+	if isExtendedPictographic(runes[0]) {
+		emojiState = emoji
+	}
+	regionalIndicators := 0
+	if prev == gbRegionalIndicator {
+		regionalIndicators = 1
+	}
+	i := 1
+	for ; i < len(runes); i++ {
+		next := graphemeBreakProperty(runes[i])
+		pictographic := isExtendedPictographic(runes[i])
+		join := false
+		switch {
+		case prev == gbCR && next == gbLF: // GB3
+			join = true
+		case prev == gbControl, prev == gbCR, prev == gbLF: // GB4
+		case next == gbControl, next == gbCR, next == gbLF: // GB5
+		case prev == gbL && (next == gbL || next == gbV || next == gbLV || next == gbLVT): // GB6
+			join = true
+		case (prev == gbLV || prev == gbV) && (next == gbV || next == gbT): // GB7
+			join = true
+		case (prev == gbLVT || prev == gbT) && next == gbT: // GB8
+			join = true
+		case next == gbExtend, next == gbZWJ, next == gbSpacingMark: // GB9, GB9a
+			join = true
+		case prev == gbPrepend: // GB9b
+			join = true
+		case emojiState == emojiJoint && pictographic: // GB11
+			join = true
+		case prev == gbRegionalIndicator && next == gbRegionalIndicator: // GB12, GB13
+			join = regionalIndicators%2 == 1
+		}
+		if !join {
+			break
+		}
+		switch {
+		case pictographic:
+			emojiState = emoji
+		case emojiState == emoji && next == gbExtend:
+		case emojiState == emoji && next == gbZWJ:
+			emojiState = emojiJoint
+		default:
+			emojiState = noEmoji
+		}
+		if next == gbRegionalIndicator {
+			regionalIndicators++
+		} else {
+			regionalIndicators = 0
+		}
+		prev = next
+	}
+	return i
+}
+
+// Graphemes splits s into extended grapheme clusters, the user-perceived
+// characters of Unicode Standard Annex #29. For example a flag, an emoji with
+// skin tone or a letter followed by combining diacritical marks is one
+// cluster.
+func Graphemes(s string) []string {
+	runes := []rune(s)
+	clusters := make([]string, 0, len(runes))
+	for len(runes) > 0 {
+		n := graphemeLength(runes)
+		clusters = append(clusters, string(runes[:n]))
+		runes = runes[n:]
+	}
+	return clusters
+}
+
+// GraphemeWidth returns the number of terminal columns the grapheme cluster g
+// occupies (see Graphemes).
+func GraphemeWidth(g string) int {
+	return clusterWidth([]rune(g))
+}
+
+// clusterWidth returns the number of terminal columns the grapheme cluster
+// occupies. Flags (regional indicator pairs) and emoji presentation sequences
+// are wide, otherwise the widest rune of the cluster decides.
+func clusterWidth(cluster []rune) int {
+	if len(cluster) == 0 {
+		return 0
+	}
+	if len(cluster) > 1 {
+		if isRegionalIndicator(cluster[0]) && isRegionalIndicator(cluster[1]) {
+			return 2
+		}
+		if isExtendedPictographic(cluster[0]) {
+			for _, r := range cluster[1:] {
+				if r == 0xFE0F {
+					return 2
+				}
+			}
+		}
+	}
+	w := 0
+	for _, r := range cluster {
+		if rw := RuneWidth(r); rw > w {
+			w = rw
+		}
+	}
+	return w
+}
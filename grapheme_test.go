@@ -0,0 +1,67 @@
+package blox_test
+
+import (
+	"testing"
+
+	"github.com/sa6mwa/blox"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraphemes(t *testing.T) {
+	cases := []struct {
+		input  string
+		expect []string
+	}{
+		{"abc", []string{"a", "b", "c"}},
+		{"A\u030aA\u0308", []string{"A\u030a", "A\u0308"}},
+		{"\r\n\n", []string{"\r\n", "\n"}},
+		{"\U0001F1F8\U0001F1EA\U0001F1F3", []string{"\U0001F1F8\U0001F1EA", "\U0001F1F3"}},
+		{"\U0001F44D\U0001F3FDx", []string{"\U0001F44D\U0001F3FD", "x"}},
+		{"\U0001F468\u200d\U0001F469\u200d\U0001F467!", []string{"\U0001F468\u200d\U0001F469\u200d\U0001F467", "!"}},
+		{"\u2764\ufe0f", []string{"\u2764\ufe0f"}},
+		{"\u1100\u1161\u11a8\uac00", []string{"\u1100\u1161\u11a8", "\uac00"}},
+		{"a\u200db", []string{"a\u200d", "b"}},
+		{"\u0915\u093f", []string{"\u0915\u093f"}},
+		{"", []string{}},
+	}
+	for i, tc := range cases {
+		assert.Equal(t, tc.expect, blox.Graphemes(tc.input), "case %d", i)
+	}
+}
+
+func TestGraphemeWidth(t *testing.T) {
+	assert.Equal(t, 1, blox.GraphemeWidth("A\u030a"))
+	assert.Equal(t, 2, blox.GraphemeWidth("\U0001F1F8\U0001F1EA"))
+	assert.Equal(t, 2, blox.GraphemeWidth("\U0001F468\u200d\U0001F469\u200d\U0001F467"))
+	assert.Equal(t, 2, blox.GraphemeWidth("\u2764\ufe0f"))
+	assert.Equal(t, 1, blox.GraphemeWidth("\u2764"))
+	assert.Equal(t, 2, blox.StringWidth("\U0001F468\u200d\U0001F469\u200d\U0001F467"))
+}
+
+func TestPutLineGraphemes(t *testing.T) {
+	family := "\U0001F468\u200d\U0001F469\u200d\U0001F467"
+	flag := "\U0001F1F8\U0001F1EA"
+	line := "A\u030a" + flag + family + "!"
+	b := blox.New().SetColumnsAndRows(10, 1).Trim()
+	b.PutLine([]rune(line))
+	assert.Equal(t, line+blox.LineBreak, b.String())
+	assert.Equal(t, []rune(line+blox.LineBreak), b.Runes())
+	assert.Equal(t, 6, b.Cursor.X)
+	assert.Len(t, b.Cells()[0], 4)
+
+	// Move steps by character, a wide cluster is one step.
+	b.Move(0, 0).MoveRight(2)
+	assert.Equal(t, 3, b.Cursor.X)
+	b.MoveRight()
+	assert.Equal(t, 5, b.Cursor.X)
+	b.MoveLeft(2)
+	assert.Equal(t, 1, b.Cursor.X)
+}
+
+func TestPutTextDecomposed(t *testing.T) {
+	decomposed := "A\u030aA\u0308O\u0308A\u030aA\u0308O\u0308"
+	b := blox.New().SetColumnsAndRows(10, 1).Trim()
+	b.PutLine([]rune(decomposed))
+	assert.Equal(t, 6, b.Cursor.X)
+	assert.Equal(t, decomposed+blox.LineBreak, b.String())
+}
@@ -0,0 +1,74 @@
+package blox
+
+// SetAutoGrow enables or disables growing the canvas when something is written
+// beyond the right edge (columns) or below the bottom row (rows). The canvas
+// grows just enough to fit what is written, existing content keeps its
+// position. Moving the cursor beyond the canvas does not grow it, but the
+// cursor is not clamped to the last column or row either (Cursor.OffCanvas is
+// true until something is written there). See SetMaxSize to limit the growth.
+//
+//	b := blox.New().Trim().SetAutoGrow(true, true)
+//	b.PutText(report) // Columns and Rows now fit the report.
+//
+// Growing is not possible for a Region.
+func (b *Blox) SetAutoGrow(columns bool, rows bool) *Blox {
+	b.AutoGrowColumns = columns
+	b.AutoGrowRows = rows
+	return b
+}
+
+// SetMaxSize limits how far the canvas can grow in auto-grow mode (see
+// SetAutoGrow), 0 is no limit. Writes beyond the limit are clipped as usual.
+func (b *Blox) SetMaxSize(columns int, rows int) *Blox {
+	b.MaxColumns = columns
+	b.MaxRows = rows
+	return b
+}
+
+// canGrowColumns returns true if the canvas can grow to columns columns.
+func (b *Blox) canGrowColumns(columns int) bool {
+	return b.AutoGrowColumns && b.parent == nil && (b.MaxColumns <= 0 || columns <= b.MaxColumns)
+}
+
+// canGrowRows returns true if the canvas can grow to rows rows.
+func (b *Blox) canGrowRows(rows int) bool {
+	return b.AutoGrowRows && b.parent == nil && (b.MaxRows <= 0 || rows <= b.MaxRows)
+}
+
+// noCanvas returns true if there is nothing to write to and no way to grow.
+func (b *Blox) noCanvas() bool {
+	return (b.Columns == 0 && !b.canGrowColumns(1)) || (b.Rows == 0 && !b.canGrowRows(1))
+}
+
+// fit returns true if column x, row y is on the canvas, growing the canvas
+// to include it if possible.
+func (b *Blox) fit(x int, y int) bool {
+	if b.cellIndex(x, y) >= 0 {
+		return true
+	}
+	ax, ay := x+b.originX, y+b.originY
+	return ax >= 0 && ay >= 0 && b.grow(ax+1, ay+1) && b.cellIndex(x, y) >= 0
+}
+
+// grow grows the canvas to at least columns and rows as far as auto-grow mode
+// and the maximum size allow. Returns true if the size changed.
+func (b *Blox) grow(columns int, rows int) bool {
+	newColumns, newRows := b.Columns, b.Rows
+	if columns > newColumns && b.canGrowColumns(newColumns+1) {
+		newColumns = columns
+		if b.MaxColumns > 0 && newColumns > b.MaxColumns {
+			newColumns = b.MaxColumns
+		}
+	}
+	if rows > newRows && b.canGrowRows(newRows+1) {
+		newRows = rows
+		if b.MaxRows > 0 && newRows > b.MaxRows {
+			newRows = b.MaxRows
+		}
+	}
+	if newColumns == b.Columns && newRows == b.Rows {
+		return false
+	}
+	b.resize(newColumns, newRows)
+	return true
+}
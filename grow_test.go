@@ -0,0 +1,75 @@
+package blox_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/sa6mwa/blox"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetAutoGrow(t *testing.T) {
+	b := blox.New().Trim().SetAutoGrow(true, true)
+	b.PutText("Hello" + blox.LineBreak + "world!")
+	assert.Equal(t, 6, b.Columns)
+	assert.Equal(t, 2, b.Rows)
+	assert.Equal(t, "Hello"+blox.LineBreak+"world!"+blox.LineBreak, b.String())
+
+	// Growing the columns keeps the rows intact.
+	b.SetForeground(blox.Red).Move(8, 0).PutText("世")
+	assert.Equal(t, 10, b.Columns)
+	assert.Equal(t, []string{"Hello   世", "world!"}, b.Strings())
+	assert.Equal(t, blox.Red, b.StyleAt(8, 0).Foreground)
+
+	b.Move(0, 5)
+	assert.True(t, b.Cursor.OffCanvas)
+	assert.Equal(t, 5, b.Cursor.Y)
+	assert.Equal(t, 2, b.Rows, "moving does not grow the canvas")
+	b.DrawBox(0, 3, 3, 3, blox.LineASCII)
+	assert.Equal(t, 6, b.Rows)
+	assert.Equal(t, []string{"Hello   世", "world!", "", "+-+", "| |", "+-+"}, b.Strings())
+}
+
+func TestSetAutoGrowLimits(t *testing.T) {
+	b := blox.New().SetColumnsAndRows(3, 1).Trim().SetAutoGrow(true, false)
+	b.PutText("one" + blox.LineBreak + "two")
+	assert.Equal(t, []string{"one"}, b.Strings())
+
+	b = blox.New().Trim().SetAutoGrow(true, true).SetMaxSize(4, 2).SetStrict(true)
+	b.PutText("abcdef" + blox.LineBreak + "gh" + blox.LineBreak + "ij")
+	assert.Equal(t, []string{"abcd", "gh"}, b.Strings())
+	assert.ErrorIs(t, b.Err(), blox.ErrOffCanvas)
+
+	// Regions do not grow.
+	b = blox.New().SetColumnsAndRows(4, 1).Trim()
+	r := b.Region(0, 0, 2, 1).SetAutoGrow(true, true).PutText("abc")
+	assert.Equal(t, 2, r.Columns)
+	assert.Equal(t, []string{"ab"}, b.Strings())
+}
+
+func TestSetAutoGrowLayers(t *testing.T) {
+	b := blox.New().Trim().SetAutoGrow(true, true).PutText("ab" + blox.LineBreak + "cd")
+	b.Layer("top").Move(1, 1).PutChar('X')
+	b.Move(3, 0).PutChar('e')
+	assert.Equal(t, []string{"ab e", "cX"}, b.Strings())
+	assert.Equal(t, 4, b.FindLayer("top").Columns)
+
+	b = blox.New().SetColumnsAndRows(4, 1).Trim()
+	dots := b.Layer("dots")
+	dots.TransparentRune = '.'
+	dots.PutText("....")
+	b.SetAutoGrow(true, true).Move(0, 0).PutText("abcdefgh")
+	assert.Equal(t, "abcdefgh"+blox.LineBreak, b.String(), "new layer cells are transparent")
+}
+
+func ExampleBlox_SetAutoGrow() {
+	b := blox.New().Trim().SetAutoGrow(true, true)
+	b.PutText("Unknown size"+blox.LineBreak+"up front").DrawBox(14, 0, 4, 3, blox.LineASCII)
+	fmt.Printf("%dx%d\n", b.Columns, b.Rows)
+	fmt.Print(b.String())
+	// Output:
+	// 18x3
+	// Unknown size  +--+
+	// up front      |  |
+	//               +--+
+}
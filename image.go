@@ -0,0 +1,306 @@
+package blox
+
+import (
+	"image"
+	"math"
+)
+
+// ImageMode selects how DrawImage renders an image.
+type ImageMode uint8
+
+const (
+	ImageASCII      ImageMode = iota // One character of Ramp per cell by luminance.
+	ImageHalfBlocks                  // 1x2 pixels per cell, see BlockHalf.
+	ImageQuadrants                   // 2x2 pixels per cell, see BlockQuadrant.
+	ImageBraille                     // 2x4 dots per cell, see Braille.
+)
+
+// Dither selects how luminance is reduced to the levels of the ramp (or to
+// on/off pixels) by DrawImage.
+type Dither uint8
+
+const (
+	DitherNone           Dither = iota // Round to the nearest level.
+	DitherFloydSteinberg               // Floyd–Steinberg error diffusion.
+	DitherOrdered                      // Ordered dithering with a 4x4 Bayer matrix.
+)
+
+// DefaultRamp is the luminance ramp of ImageASCII, from dark to bright.
+const DefaultRamp = " .:-=+*#%@"
+
+// ImageOptions controls DrawImage and PutImage. The zero value renders an
+// image in ASCII as wide as the canvas from the column it starts at.
+type ImageOptions struct {
+	Mode   ImageMode
+	Dither Dither
+	// Columns and Rows is the size in cells the image is scaled to fit in,
+	// keeping its aspect ratio. If one of them is 0, it follows from the
+	// other. If both are 0, the image is as wide as the rest of the canvas.
+	Columns int
+	Rows    int
+	// CellAspect is the height of a cell divided by its width, 2 if 0.
+	CellAspect float64
+	// Ramp is the characters of ImageASCII from dark to bright, DefaultRamp
+	// if empty.
+	Ramp string
+	// Invert swaps dark and bright, for dark images on a light background.
+	Invert bool
+	// Color draws characters, dots and blocks in the color of the image.
+	// Without Color, blocks are on/off pixels in the pen like braille dots.
+	Color bool
+}
+
+// imagePixel is the average of the pixels of an image covered by one pixel of
+// the rendered image.
+type imagePixel struct {
+	r, g, b   float64 // Color components 0-1.
+	luminance float64 // 0-1.
+	opaque    bool    // Less than half transparent.
+}
+
+// bayer4 is the 4x4 Bayer matrix of ordered dithering.
+var bayer4 = [4][4]float64{
+	{0, 8, 2, 10},
+	{12, 4, 14, 6},
+	{3, 11, 1, 9},
+	{15, 7, 13, 5},
+}
+
+// pixelsPerCell returns the number of pixels of each cell horizontally and
+// vertically in mode.
+func (m ImageMode) pixelsPerCell() (int, int) {
+	switch m {
+	case ImageHalfBlocks:
+		return 1, 2
+	case ImageQuadrants:
+		return 2, 2
+	case ImageBraille:
+		return 2, 4
+	}
+	return 1, 1
+}
+
+// ImageSize returns the size in cells img is drawn with by DrawImage on b at
+// column x.
+func (b *Blox) ImageSize(img image.Image, x int, options ImageOptions) (columns int, rows int) {
+	bounds := img.Bounds()
+	w, h := float64(bounds.Dx()), float64(bounds.Dy())
+	if w == 0 || h == 0 {
+		return 0, 0
+	}
+	aspect := options.CellAspect
+	if aspect <= 0 {
+		aspect = 2
+	}
+	columns, rows = options.Columns, options.Rows
+	if columns <= 0 && rows <= 0 {
+		columns = b.Columns - b.originX - x
+	}
+	// Rows for a width of columns and columns for a height of rows.
+	forColumns := func(columns int) int { return maxInt(1, int(math.Round(float64(columns)*h/(w*aspect)))) }
+	forRows := func(rows int) int { return maxInt(1, int(math.Round(float64(rows)*aspect*w/h))) }
+	switch {
+	case rows <= 0:
+		rows = forColumns(columns)
+	case columns <= 0:
+		columns = forRows(rows)
+	case forColumns(columns) > rows:
+		columns = forRows(rows)
+	default:
+		rows = forColumns(columns)
+	}
+	return maxInt(columns, 0), maxInt(rows, 0)
+}
+
+// DrawImage renders img (for example decoded with image/png) onto the canvas
+// with the upper left hand corner at column x, row y in the pen, scaled to
+// fit options.Columns x options.Rows cells (see ImageOptions and ImageSize).
+// Depending on the mode, each cell is one character of a luminance ramp, half
+// blocks, quadrants or braille dots. Bright parts of the image are drawn
+// (dense characters, pixels set) unless options.Invert, which suits a dark
+// terminal. Pixels that are more than half transparent are left blank. Blank
+// cells are written as spaces, so they are skipped in transparent mode (see
+// SetTransparent). Everything outside of the canvas is clipped and the cursor
+// is not moved.
+func (b *Blox) DrawImage(img image.Image, x int, y int, options ImageOptions) *Blox {
+	columns, rows := b.ImageSize(img, x, options)
+	if columns == 0 || rows == 0 {
+		return b
+	}
+	px, py := options.Mode.pixelsPerCell()
+	w, h := columns*px, rows*py
+	pixels := samplePixels(img, w, h)
+	levels := 2
+	ramp := []rune(options.Ramp)
+	if options.Mode == ImageASCII {
+		if len(ramp) == 0 {
+			ramp = []rune(DefaultRamp)
+		}
+		levels = len(ramp)
+	}
+	level := quantize(pixels, w, h, levels, options)
+	pen := b.Pen
+	switch options.Mode {
+	case ImageASCII:
+		for j := 0; j < rows; j++ {
+			for i := 0; i < columns; i++ {
+				p := pixels[j*w+i]
+				r := ' '
+				if p.opaque {
+					r = ramp[level[j*w+i]]
+				}
+				b.Pen = pen
+				if options.Color && p.opaque {
+					b.Pen.Foreground = p.color()
+				}
+				b.plot(x+i, y+j, r)
+			}
+		}
+	case ImageHalfBlocks, ImageQuadrants:
+		mode := BlockHalf
+		if options.Mode == ImageQuadrants {
+			mode = BlockQuadrant
+		}
+		surface := NewBlocks(columns, rows, mode)
+		for i, p := range pixels {
+			switch {
+			case !p.opaque:
+			case options.Color:
+				surface.SetPixel(i%w, i/w, p.color())
+			case level[i] == 1:
+				surface.SetPixel(i%w, i/w)
+			}
+		}
+		b.DrawBlocks(surface, x, y)
+	case ImageBraille:
+		surface := NewBraille(columns, rows)
+		for i, p := range pixels {
+			if p.opaque && level[i] == 1 {
+				surface.SetPixel(i%w, i/w)
+			}
+		}
+		for j := 0; j < rows; j++ {
+			for i := 0; i < columns; i++ {
+				r := surface.Rune(i, j)
+				if r == BrailleBlank {
+					r = ' '
+				}
+				b.Pen = pen
+				if options.Color {
+					b.Pen.Foreground = averageColor(pixels, w, i*px, j*py, px, py, level)
+				}
+				b.plot(x+i, y+j, r)
+			}
+		}
+	}
+	b.Pen = pen
+	return b
+}
+
+// PutImage renders img at the cursor position like DrawImage and moves the
+// cursor to the row below the image like PutText does.
+func (b *Blox) PutImage(img image.Image, options ImageOptions) *Blox {
+	x, y := b.Cursor.X, b.Cursor.Y
+	_, rows := b.ImageSize(img, x, options)
+	return b.DrawImage(img, x, y, options).Move(x, y+rows)
+}
+
+// color returns the color of p.
+func (p imagePixel) color() Color {
+	return RGB(uint8(math.Round(p.r*255)), uint8(math.Round(p.g*255)), uint8(math.Round(p.b*255)))
+}
+
+// averageColor returns the average color of the pixels set (level 1) in the
+// rectangle at x, y that is w pixels wide and h pixels high, or DefaultColor
+// if none is set.
+func averageColor(pixels []imagePixel, stride int, x int, y int, w int, h int, level []int) Color {
+	var sum imagePixel
+	n := 0
+	for j := y; j < y+h; j++ {
+		for i := x; i < x+w; i++ {
+			if p := pixels[j*stride+i]; p.opaque && level[j*stride+i] == 1 {
+				sum.r, sum.g, sum.b = sum.r+p.r, sum.g+p.g, sum.b+p.b
+				n++
+			}
+		}
+	}
+	if n == 0 {
+		return DefaultColor
+	}
+	sum.r, sum.g, sum.b = sum.r/float64(n), sum.g/float64(n), sum.b/float64(n)
+	return sum.color()
+}
+
+// samplePixels scales img to w x h pixels, each the average of the pixels of
+// img it covers (or of a grid of samples of them).
+func samplePixels(img image.Image, w int, h int) []imagePixel {
+	bounds := img.Bounds()
+	pixels := make([]imagePixel, w*h)
+	for j := 0; j < h; j++ {
+		y0 := bounds.Min.Y + j*bounds.Dy()/h
+		y1 := maxInt(y0+1, bounds.Min.Y+(j+1)*bounds.Dy()/h)
+		for i := 0; i < w; i++ {
+			x0 := bounds.Min.X + i*bounds.Dx()/w
+			x1 := maxInt(x0+1, bounds.Min.X+(i+1)*bounds.Dx()/w)
+			// At most 8x8 samples per pixel for large images.
+			stepX, stepY := maxInt(1, (x1-x0)/8), maxInt(1, (y1-y0)/8)
+			var r, g, b, a, n float64
+			for sy := y0; sy < y1; sy += stepY {
+				for sx := x0; sx < x1; sx += stepX {
+					cr, cg, cb, ca := img.At(sx, sy).RGBA()
+					r, g, b, a = r+float64(cr), g+float64(cg), b+float64(cb), a+float64(ca)
+					n++
+				}
+			}
+			p := imagePixel{opaque: a/n >= 0xffff/2}
+			if a > 0 {
+				// Color components are premultiplied by alpha.
+				p.r, p.g, p.b = r/a, g/a, b/a
+			}
+			p.luminance = 0.2126*p.r + 0.7152*p.g + 0.0722*p.b
+			pixels[j*w+i] = p
+		}
+	}
+	return pixels
+}
+
+// quantize returns the level (0 to levels-1) of the luminance of each pixel,
+// dithered according to options.
+func quantize(pixels []imagePixel, w int, h int, levels int, options ImageOptions) []int {
+	level := make([]int, len(pixels))
+	values := make([]float64, len(pixels))
+	for i, p := range pixels {
+		values[i] = p.luminance
+		if options.Invert {
+			values[i] = 1 - values[i]
+		}
+	}
+	steps := float64(levels - 1)
+	if steps <= 0 {
+		return level
+	}
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			v := values[j*w+i]
+			if options.Dither == DitherOrdered {
+				v += ((bayer4[j%4][i%4]+0.5)/16 - 0.5) / steps
+			}
+			q := int(math.Round(math.Max(0, math.Min(1, v)) * steps))
+			level[j*w+i] = q
+			if options.Dither != DitherFloydSteinberg || !pixels[j*w+i].opaque {
+				continue
+			}
+			e := v - float64(q)/steps
+			spread := func(i int, j int, weight float64) {
+				if i >= 0 && i < w && j < h {
+					values[j*w+i] += e * weight
+				}
+			}
+			spread(i+1, j, 7.0/16)
+			spread(i-1, j+1, 3.0/16)
+			spread(i, j+1, 5.0/16)
+			spread(i+1, j+1, 1.0/16)
+		}
+	}
+	return level
+}
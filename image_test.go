@@ -0,0 +1,164 @@
+package blox_test
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/sa6mwa/blox"
+	"github.com/stretchr/testify/assert"
+)
+
+// gradient returns a w x h image going from black on the left to white on the
+// right.
+func gradient(w int, h int) *image.Gray {
+	img := image.NewGray(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.SetGray(x, y, color.Gray{Y: uint8(x * 255 / (w - 1))})
+		}
+	}
+	return img
+}
+
+// disc returns a size x size image of a white disc on a transparent
+// background.
+func disc(size int) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, size, size))
+	r := float64(size) / 2
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			dx, dy := float64(x)+0.5-r, float64(y)+0.5-r
+			if dx*dx+dy*dy <= r*r {
+				img.SetNRGBA(x, y, color.NRGBA{R: 255, G: 255, B: 255, A: 255})
+			}
+		}
+	}
+	return img
+}
+
+func TestImageSize(t *testing.T) {
+	b := blox.New().SetColumnsAndRows(40, 10)
+	img := gradient(100, 50)
+	columns, rows := b.ImageSize(img, 0, blox.ImageOptions{})
+	assert.Equal(t, 40, columns)
+	assert.Equal(t, 10, rows)
+	columns, rows = b.ImageSize(img, 30, blox.ImageOptions{})
+	assert.Equal(t, 10, columns)
+	assert.Equal(t, 3, rows)
+	columns, rows = b.ImageSize(img, 0, blox.ImageOptions{Rows: 5})
+	assert.Equal(t, 20, columns)
+	assert.Equal(t, 5, rows)
+	columns, rows = b.ImageSize(img, 0, blox.ImageOptions{Columns: 20, Rows: 2})
+	assert.Equal(t, 8, columns, "fit keeping the aspect ratio")
+	assert.Equal(t, 2, rows)
+	columns, rows = b.ImageSize(img, 0, blox.ImageOptions{Columns: 20, Rows: 20, CellAspect: 1})
+	assert.Equal(t, 20, columns)
+	assert.Equal(t, 10, rows)
+	columns, rows = b.ImageSize(image.NewGray(image.Rect(0, 0, 0, 0)), 0, blox.ImageOptions{})
+	assert.Equal(t, 0, columns)
+	assert.Equal(t, 0, rows)
+}
+
+func TestDrawImageASCII(t *testing.T) {
+	img := gradient(10, 2)
+	b := blox.New().SetColumnsAndRows(12, 2).Trim().Move(0, 1)
+	b.DrawImage(img, 1, 0, blox.ImageOptions{Columns: 10})
+	assert.Equal(t, []string{"  .:-=+*#%@"}, b.Strings())
+	assert.Equal(t, 0, b.Cursor.X, "the cursor is not moved")
+	assert.Equal(t, 1, b.Cursor.Y)
+
+	b = blox.New().SetColumnsAndRows(10, 1).Trim()
+	b.DrawImage(img, 0, 0, blox.ImageOptions{Ramp: " #", Invert: true})
+	assert.Equal(t, []string{"#####"}, b.Strings())
+
+	b = blox.New().SetColumnsAndRows(8, 1)
+	b.DrawImage(gradient(8, 1), 0, 0, blox.ImageOptions{Columns: 8, Rows: 1, CellAspect: 8, Color: true})
+	cells := b.Cells()
+	assert.Equal(t, blox.RGB(255, 255, 255), cells[0][7].Style.Foreground)
+	assert.Equal(t, blox.RGB(36, 36, 36), cells[0][1].Style.Foreground)
+
+	b = blox.New().SetColumnsAndRows(4, 1).Trim()
+	b.DrawImage(image.NewUniform(color.Gray{Y: 255}), 0, 0, blox.ImageOptions{Rows: 1, CellAspect: 4})
+	assert.Equal(t, []string{"@@@@"}, b.Strings(), "an image of infinite size")
+}
+
+func TestDrawImageDither(t *testing.T) {
+	gray := image.NewGray(image.Rect(0, 0, 8, 4))
+	for i := range gray.Pix {
+		gray.Pix[i] = 128
+	}
+	for _, dither := range []blox.Dither{blox.DitherFloydSteinberg, blox.DitherOrdered} {
+		b := blox.New().SetColumnsAndRows(8, 4)
+		b.DrawImage(gray, 0, 0, blox.ImageOptions{Columns: 8, CellAspect: 1, Ramp: " #", Dither: dither})
+		dots := 0
+		for _, line := range b.Strings() {
+			dots += bytes.Count([]byte(line), []byte("#"))
+		}
+		assert.InDelta(t, 16, dots, 1, "half of the cells for 50% gray (dither %d)", dither)
+	}
+	b := blox.New().SetColumnsAndRows(8, 4).Trim()
+	b.DrawImage(gray, 0, 0, blox.ImageOptions{Columns: 8, CellAspect: 1, Ramp: " #"})
+	assert.Equal(t, []string{"########", "########", "########", "########"}, b.Strings(), "no dithering")
+}
+
+func TestDrawImageBlocks(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 2, 2))
+	img.SetNRGBA(0, 0, color.NRGBA{R: 255, A: 255})
+	img.SetNRGBA(0, 1, color.NRGBA{B: 255, A: 255})
+	img.SetNRGBA(1, 1, color.NRGBA{G: 255, A: 255})
+	b := blox.New().SetColumnsAndRows(2, 1).Trim()
+	b.DrawImage(img, 0, 0, blox.ImageOptions{Mode: blox.ImageHalfBlocks, Columns: 2, Color: true})
+	assert.Equal(t, []string{"▀▄"}, b.Strings())
+	cells := b.Cells()
+	assert.Equal(t, blox.Style{Foreground: blox.RGB(255, 0, 0), Background: blox.RGB(0, 0, 255)}, cells[0][0].Style)
+	assert.Equal(t, blox.Style{Foreground: blox.RGB(0, 255, 0)}, cells[0][1].Style)
+
+	b = blox.New().SetColumnsAndRows(1, 1).Trim()
+	b.DrawImage(img, 0, 0, blox.ImageOptions{Mode: blox.ImageQuadrants, Columns: 1})
+	assert.Equal(t, []string{"▗"}, b.Strings())
+}
+
+func TestDrawImageBraille(t *testing.T) {
+	b := blox.New().SetColumnsAndRows(8, 2).Trim()
+	b.DrawImage(disc(16), 0, 0, blox.ImageOptions{Mode: blox.ImageBraille, Columns: 4})
+	assert.Equal(t, []string{"⣴⣿⣿⣦", "⠻⣿⣿⠟"}, b.Strings())
+}
+
+func TestPutImage(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, png.Encode(&buf, disc(8)))
+	img, err := png.Decode(&buf)
+	if !assert.NoError(t, err) {
+		return
+	}
+	b := blox.New().SetColumnsAndRows(10, 6).Trim().Move(1, 1)
+	b.PutImage(img, blox.ImageOptions{Columns: 8}).PutText("x")
+	expect := []string{
+		"",
+		"  @@@@@@",
+		" @@@@@@@@",
+		" @@@@@@@@",
+		"  @@@@@@",
+		" x",
+	}
+	assert.Equal(t, expect, b.Strings())
+}
+
+func ExampleBlox_DrawImage() {
+	b := blox.New().SetColumnsAndRows(23, 7).Trim().DrawBox(0, 0, 23, 7, blox.LineLight)
+	b.DrawImage(disc(40), 1, 1, blox.ImageOptions{Mode: blox.ImageBraille, Columns: 10})
+	b.DrawImage(gradient(20, 10), 12, 1, blox.ImageOptions{Columns: 10, Dither: blox.DitherOrdered, Ramp: " .oO@"})
+	fmt.Println(b.String())
+	// Output:
+	// ┌─────────────────────┐
+	// │ ⣠⣴⣿⣿⣿⣿⣦⣄   ..o.ooOO@│
+	// │⣴⣿⣿⣿⣿⣿⣿⣿⣿⣦   ..ooOO@@│
+	// │⣿⣿⣿⣿⣿⣿⣿⣿⣿⣿  ....ooOO@│
+	// │⠻⣿⣿⣿⣿⣿⣿⣿⣿⠟           │
+	// │ ⠙⠻⣿⣿⣿⣿⠟⠋            │
+	// └─────────────────────┘
+}
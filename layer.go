@@ -0,0 +1,173 @@
+package blox
+
+import "sort"
+
+// Layer is a named canvas drawn on top of the canvas of a Blox. Each layer has
+// its own cursor, pen and trim settings (it is a Blox of its own) and always
+// has the same size as the Blox it belongs to. Layers are composited on top of
+// each other in Z order (lowest first) by Lines, Cells and all functions
+// producing output. The canvas of the Blox itself is the bottom-most layer.
+//
+// Cells in a layer holding the transparent rune of the layer (space unless
+// TransparentRune is set) in a style that is not visible (see Cells) let what
+// is underneath through, unless the layer is Opaque.
+type Layer struct {
+	*Blox
+	Name    string
+	Z       int
+	Visible bool
+	Opaque  bool
+}
+
+// AddLayer returns the canvas of layer name, creating it with the size of b if
+// it does not exist. z positions the layer in the stack, higher is on top.
+func (b *Blox) AddLayer(name string, z int) *Blox {
+	if l := b.FindLayer(name); l != nil {
+		l.Z = z
+		return l.Blox
+	}
+	l := &Layer{
+		Blox:    New(),
+		Name:    name,
+		Z:       z,
+		Visible: true,
+	}
+	l.SetColumnsAndRows(b.Columns, b.Rows)
+	b.Layers = append(b.Layers, l)
+	return l.Blox
+}
+
+// Layer returns the canvas of layer name. If the layer does not exist it is
+// created on top of all other layers.
+func (b *Blox) Layer(name string) *Blox {
+	if l := b.FindLayer(name); l != nil {
+		return l.Blox
+	}
+	z := 0
+	for _, l := range b.Layers {
+		if l.Z >= z {
+			z = l.Z + 1
+		}
+	}
+	return b.AddLayer(name, z)
+}
+
+// FindLayer returns layer name or nil if there is no such layer.
+func (b *Blox) FindLayer(name string) *Layer {
+	for _, l := range b.Layers {
+		if l.Name == name {
+			return l
+		}
+	}
+	return nil
+}
+
+// RemoveLayer removes layer name and its content.
+func (b *Blox) RemoveLayer(name string) *Blox {
+	for i, l := range b.Layers {
+		if l.Name == name {
+			b.Layers = append(b.Layers[:i], b.Layers[i+1:]...)
+			break
+		}
+	}
+	return b
+}
+
+// ShowLayer makes layer name visible in the output.
+func (b *Blox) ShowLayer(name string) *Blox {
+	if l := b.FindLayer(name); l != nil {
+		l.Visible = true
+	}
+	return b
+}
+
+// HideLayer hides layer name from the output without removing it.
+func (b *Blox) HideLayer(name string) *Blox {
+	if l := b.FindLayer(name); l != nil {
+		l.Visible = false
+	}
+	return b
+}
+
+// SetLayerZ moves layer name to position z in the stack.
+func (b *Blox) SetLayerZ(name string, z int) *Blox {
+	if l := b.FindLayer(name); l != nil {
+		l.Z = z
+	}
+	return b
+}
+
+// resizeLayers resizes all layers to the size of b, new cells are filled with
+// the transparent rune of each layer.
+func (b *Blox) resizeLayers() {
+	for _, l := range b.Layers {
+		if l.Columns != b.Columns || l.Rows != b.Rows {
+			l.relayout(b.Columns, b.Rows, 0, 0, l.transparentRune())
+			l.Move(l.Cursor.X, l.Cursor.Y)
+		}
+	}
+}
+
+// transparentRune returns the rune letting layers below through, space unless
+// TransparentRune is set.
+func (l *Layer) transparentRune() rune {
+	if l.TransparentRune == 0 {
+		return ' '
+	}
+	return l.TransparentRune
+}
+
+// transparentAt returns true if the cell at canvas index i lets layers below
+// through.
+func (l *Layer) transparentAt(i int) bool {
+	if l.Opaque {
+		return false
+	}
+	return l.Canvas[i] == l.transparentRune() && len(l.Combining[i]) == 0 && !l.styleAtIndex(i).visibleWhenBlank()
+}
+
+// composite returns b if there are no visible layers, otherwise a copy of b
+// with all visible layers flattened onto its canvas.
+func (b *Blox) composite() *Blox {
+	layers := make([]*Layer, 0, len(b.Layers))
+	for _, l := range b.Layers {
+		if l.Visible {
+			layers = append(layers, l)
+		}
+	}
+	if len(layers) == 0 {
+		return b
+	}
+	sort.SliceStable(layers, func(i, j int) bool {
+		return layers[i].Z < layers[j].Z
+	})
+	c := &Blox{
+		Columns:             b.Columns,
+		Rows:                b.Rows,
+		TrimRightSpaces:     b.TrimRightSpaces,
+		TrimFinalEmptyLines: b.TrimFinalEmptyLines,
+		Canvas:              make([]rune, len(b.Canvas)),
+		Styles:              make([]Style, len(b.Canvas)),
+		Combining:           make(map[int][]rune, len(b.Combining)),
+	}
+	copy(c.Canvas, b.Canvas)
+	copy(c.Styles, b.Styles)
+	for i, marks := range b.Combining {
+		c.Combining[i] = marks
+	}
+	for _, l := range layers {
+		for y := 0; y < c.Rows; y++ {
+			for x := 0; x < c.Columns; x++ {
+				i := l.index(x, y)
+				if i < 0 || l.transparentAt(i) {
+					continue
+				}
+				c.Pen = l.styleAtIndex(i)
+				if dst := c.setCell(x, y, l.Canvas[i]); dst >= 0 && len(l.Combining[i]) > 0 {
+					c.Combining[dst] = l.Combining[i]
+				}
+			}
+		}
+	}
+	return c
+}
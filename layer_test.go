@@ -0,0 +1,79 @@
+package blox_test
+
+import (
+	"testing"
+
+	"github.com/sa6mwa/blox"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLayers(t *testing.T) {
+	b := blox.New().SetColumnsAndRows(8, 3).Trim()
+	b.PutText("........" + blox.LineBreak + "........" + blox.LineBreak + "........")
+
+	b.AddLayer("data", 1).Move(1, 1).PutText("12 34")
+	b.AddLayer("popup", 2).Move(3, 0).PutText("+--+" + blox.LineBreak + "|  |" + blox.LineBreak + "+--+")
+	b.Layer("popup").Move(4, 1).SetBackground(blox.Blue).PutText("  ")
+
+	// The styled spaces in the popup are opaque.
+	expect := "...+--+." + blox.LineBreak +
+		".12|  |." + blox.LineBreak +
+		"...+--+." + blox.LineBreak
+	assert.Equal(t, expect, b.String())
+
+	// The base canvas is untouched and has its own cursor.
+	assert.Equal(t, "........", string(b.Canvas[:8]))
+	assert.Equal(t, 0, b.Cursor.X)
+
+	b.HideLayer("popup")
+	expect = "........" + blox.LineBreak +
+		".12.34.." + blox.LineBreak +
+		"........" + blox.LineBreak
+	assert.Equal(t, expect, b.String())
+
+	b.ShowLayer("popup").SetLayerZ("popup", 0)
+	expect = "...+--+." + blox.LineBreak +
+		".12|34|." + blox.LineBreak +
+		"...+--+." + blox.LineBreak
+	assert.Equal(t, expect, b.String())
+
+	// An opaque layer covers everything below.
+	b.FindLayer("popup").Opaque = true
+	b.SetLayerZ("popup", 2)
+	expect = "   +--+" + blox.LineBreak +
+		"   |  |" + blox.LineBreak +
+		"   +--+" + blox.LineBreak
+	assert.Equal(t, expect, b.String())
+
+	b.RemoveLayer("data").RemoveLayer("popup")
+	assert.Nil(t, b.FindLayer("data"))
+	assert.Empty(t, b.Layers)
+}
+
+func TestLayerOrderAndResize(t *testing.T) {
+	b := blox.New().SetColumnsAndRows(3, 1).Trim()
+	b.Layer("a").PutText("aaa")
+	b.Layer("b").PutText(" b")
+	assert.Equal(t, "aba"+blox.LineBreak, b.String())
+	b.Layer("a").Move(0, 0).SetBackground(blox.Red).PutText(" ")
+	assert.Equal(t, " ba"+blox.LineBreak, b.String())
+	assert.Equal(t, blox.Red, b.Cells()[0][0].Style.Background)
+
+	b.SetColumns(5)
+	assert.Equal(t, 5, b.Layer("a").Columns)
+	assert.Equal(t, 5, b.Layer("b").Columns)
+
+	b = blox.New().SetColumnsAndRows(2, 1).Trim().PutText("ab")
+	b.Layer("dots").TransparentRune = '.'
+	b.Layer("dots").PutText("..")
+	b.SetColumns(4).Move(2, 0).PutText("cd")
+	assert.Equal(t, "abcd"+blox.LineBreak, b.String())
+}
+
+func TestLayerWideCharacters(t *testing.T) {
+	b := blox.New().SetColumnsAndRows(4, 1).Trim().PutText("日本")
+	b.Layer("top").Move(1, 0).PutChar('x')
+	assert.Equal(t, " x本"+blox.LineBreak, b.String())
+	b.Layer("top").Move(0, 0).PutText("語")
+	assert.Equal(t, "語本"+blox.LineBreak, b.String())
+}
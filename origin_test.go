@@ -0,0 +1,93 @@
+package blox_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/sa6mwa/blox"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNegativeCoordinates(t *testing.T) {
+	b := blox.New().SetColumnsAndRows(6, 3).Trim()
+	b.Move(-2, -1).PutText("abcdef" + blox.LineBreak + "ghijkl" + blox.LineBreak + "mnopqr")
+	assert.Equal(t, []string{"ijkl", "opqr"}, b.Strings())
+	assert.Equal(t, -2, b.Cursor.X)
+	assert.Equal(t, 2, b.Cursor.Y)
+
+	b = blox.New().SetColumnsAndRows(6, 2).Trim()
+	b.Move(-1, 0).PutText("世界x")
+	assert.Equal(t, []string{" 界x"}, b.Strings(), "half of a wide character clipped at the left edge")
+
+	b = blox.New().SetColumnsAndRows(6, 3).Trim().DrawBox(-2, -1, 5, 3, blox.LineASCII)
+	assert.Equal(t, []string{"  |", "--+"}, b.Strings())
+
+	src := blox.New().SetColumnsAndRows(3, 2).PutText("abc" + blox.LineBreak + "def")
+	b = blox.New().SetColumnsAndRows(4, 2).Trim().Blit(src, 0, 0, 3, 2, -1, 1)
+	assert.Equal(t, []string{"", "bc"}, b.Strings())
+
+	b = blox.New().SetColumnsAndRows(5, 2).Trim().Move(-1, 5).PutLine([]rune("abc"))
+	assert.Empty(t, b.Strings(), "a row below the canvas is clipped whatever the column")
+
+	b = blox.New().SetColumnsAndRows(5, 3).Trim().Move(2, -1).DrawVerticalLine(-1, 1)
+	assert.Equal(t, []string{"  |", "  |"}, b.Strings())
+	b = blox.New().SetColumnsAndRows(5, 5).Trim().Move(-2, 0).DrawVerticalLine(0, 4)
+	assert.Empty(t, b.Strings())
+	b = blox.New().SetColumnsAndRows(5, 3).Trim().Move(4, -2).DrawVerticalLine(-2, 5)
+	assert.Equal(t, []string{"    |", "    |", "    |"}, b.Strings())
+
+	b = blox.New().SetColumnsAndRows(4, 2).SetStrict(true).Move(-1, 0).PutText("ab")
+	assert.ErrorIs(t, b.Err(), blox.ErrOffCanvas)
+}
+
+func TestPushOrigin(t *testing.T) {
+	b := blox.New().SetColumnsAndRows(12, 5).Trim()
+	b.PushOrigin(2, 1).PutText("A").DrawBox(2, 0, 3, 3, blox.LineASCII)
+	x, y := b.Origin()
+	assert.Equal(t, 2, x)
+	assert.Equal(t, 1, y)
+	b.PushOrigin(6, 1).PutText("B").Region(0, 1, 2, 1).PutText("xyz")
+	x, y = b.Origin()
+	assert.Equal(t, 8, x)
+	assert.Equal(t, 2, y)
+	assert.Equal(t, 0, b.Cursor.X)
+	assert.Equal(t, 1, b.Cursor.Y)
+	b.PopOrigin()
+	assert.Equal(t, 6, b.Cursor.X, "the cursor stays in place")
+	assert.Equal(t, 2, b.Cursor.Y)
+	b.PopOrigin().PopOrigin().Move(0, 4).PutText("C")
+	expect := []string{
+		"",
+		"  A +-+",
+		"    | | B",
+		"    +-+ xy",
+		"C",
+	}
+	assert.Equal(t, expect, b.Strings())
+}
+
+func TestPushOriginOffCanvas(t *testing.T) {
+	b := blox.New().SetColumnsAndRows(5, 2).Trim()
+	b.PushOrigin(-3, 0).PutText("banner").MoveY(1).DrawSeparator()
+	assert.Equal(t, []string{"ner", "-----"}, b.Strings())
+	b.PopOrigin()
+	b.PushOrigin(3, 0).PutTextRightAligned("R")
+	assert.Equal(t, []string{"ner R", "-----"}, b.Strings())
+}
+
+func ExampleBlox_PushOrigin() {
+	b := blox.New().SetColumnsAndRows(20, 3).Trim()
+	badge := func(b *blox.Blox, text string) {
+		b.DrawBox(0, 0, len(text)+2, 3, blox.LineLight).Move(1, 1).PutText(text)
+	}
+	for i, text := range []string{"one", "two", "three"} {
+		b.PushOrigin(i*6, 0)
+		badge(b, text)
+		b.PopOrigin()
+	}
+	fmt.Print(b.String())
+	// Output:
+	// ┌───┐ ┌───┐ ┌─────┐
+	// │one│ │two│ │three│
+	// └───┘ └───┘ └─────┘
+}
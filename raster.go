@@ -0,0 +1,316 @@
+package blox
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+)
+
+// RasterOptions controls Rasterize and WritePNG.
+type RasterOptions struct {
+	CellWidth  int         // Width of a cell in pixels, 12 if 0.
+	CellHeight int         // Height of a cell in pixels, 24 if 0.
+	Padding    int         // Pixels of background around the canvas.
+	Foreground color.Color // Color of text in the default color, black if nil.
+	Background color.Color // Color of the background and padding, white if nil.
+}
+
+// Rasterize draws the canvas (its Cells, so trimming and layers apply like
+// for Lines) into an image with a built-in monospace bitmap font covering
+// ASCII and Latin-1. Box drawing characters, block elements and braille
+// patterns are drawn to fill the cell, so lines and pixel graphics connect.
+// Characters the font has no glyph for are drawn as a box. Cell colors and the
+// bold, dim, italic, underline and reverse attributes are honored. The font
+// is 6x12 pixels per cell and looks best when the cell size is a multiple of
+// it. Use WritePNG or image/png to encode the image.
+func (b *Blox) Rasterize(options RasterOptions) *image.RGBA {
+	w, h := options.CellWidth, options.CellHeight
+	if w <= 0 {
+		w = 2 * fontCellColumns
+	}
+	if h <= 0 {
+		h = 2 * fontCellRows
+	}
+	fg, bg := color.RGBAModel.Convert(color.Black).(color.RGBA), color.RGBAModel.Convert(color.White).(color.RGBA)
+	if options.Foreground != nil {
+		fg = color.RGBAModel.Convert(options.Foreground).(color.RGBA)
+	}
+	if options.Background != nil {
+		bg = color.RGBAModel.Convert(options.Background).(color.RGBA)
+	}
+	rows := b.Cells()
+	pad := maxInt(options.Padding, 0)
+	img := image.NewRGBA(image.Rect(0, 0, 2*pad+b.Columns*w, 2*pad+len(rows)*h))
+	draw.Draw(img, img.Bounds(), image.NewUniform(bg), image.Point{}, draw.Src)
+	for y, row := range rows {
+		x := 0
+		for _, c := range row {
+			width := maxInt(1, clusterWidth(append([]rune{c.Rune}, c.Combining...)))
+			rect := image.Rect(pad+x*w, pad+y*h, pad+(x+width)*w, pad+(y+1)*h)
+			rasterizeCell(img, rect, c, fg, bg)
+			x += width
+		}
+	}
+	return img
+}
+
+// WritePNG writes the canvas rasterized with options (see Rasterize) as a PNG
+// image to w.
+func (b *Blox) WritePNG(w io.Writer, options RasterOptions) error {
+	return png.Encode(w, b.Rasterize(options))
+}
+
+// rgba returns c as color.RGBA or def if c is the default color.
+func rgba(c Color, def color.RGBA) color.RGBA {
+	if r, g, b, ok := c.ToRGB(); ok {
+		return color.RGBA{R: r, G: g, B: b, A: 0xff}
+	}
+	return def
+}
+
+// rasterizeCell draws cell c into rect of img, defaultFg and defaultBg are the
+// colors of the default foreground and background.
+func rasterizeCell(img *image.RGBA, rect image.Rectangle, c Cell, defaultFg color.RGBA, defaultBg color.RGBA) {
+	fg, bg := rgba(c.Style.Foreground, defaultFg), rgba(c.Style.Background, defaultBg)
+	if c.Style.Has(AttrReverse) {
+		fg, bg = bg, fg
+	}
+	if c.Style.Has(AttrDim) {
+		fg = color.RGBA{
+			R: uint8((int(fg.R) + int(bg.R)) / 2),
+			G: uint8((int(fg.G) + int(bg.G)) / 2),
+			B: uint8((int(fg.B) + int(bg.B)) / 2),
+			A: 0xff,
+		}
+	}
+	if bg != defaultBg {
+		draw.Draw(img, rect, image.NewUniform(bg), image.Point{}, draw.Src)
+	}
+	w, h := rect.Dx(), rect.Dy()
+	lit := cellMask(c, w, h)
+	for py := 0; py < h; py++ {
+		for px := 0; px < w; px++ {
+			underline := c.Style.Has(AttrUnderline) && py*fontCellRows/h == fontRows-1
+			if underline || lit != nil && lit(px, py) {
+				img.SetRGBA(rect.Min.X+px, rect.Min.Y+py, fg)
+			}
+		}
+	}
+}
+
+// cellMask returns a function telling which pixels of a cell w x h pixels
+// large are lit (drawn in the foreground color) by c, or nil if none are.
+func cellMask(c Cell, w int, h int) func(int, int) bool {
+	r := c.Rune
+	if r == ' ' || r == 0 {
+		return nil
+	}
+	if d, ok := dashedLines[r]; ok {
+		return dashedMask(d.arms, d.dashes, w, h)
+	}
+	if a, ok := runeArms[r]; ok {
+		return boxMask(a, w, h)
+	}
+	switch {
+	case r >= 0x2571 && r <= 0x2573:
+		return diagonalMask(r, w, h)
+	case r >= 0x2580 && r <= 0x259f:
+		return blockMask(r, w, h)
+	case r >= BrailleBlank && r <= BrailleBlank+0xff:
+		return brailleMask(r, w, h)
+	}
+	g, ok := fontGlyph(r, c.Combining)
+	if !ok {
+		// A box for characters without a glyph.
+		top, bottom := 2*h/fontCellRows, (fontRows-2)*h/fontCellRows
+		return func(px, py int) bool {
+			inside := px >= 1 && px <= w-2 && py >= top && py <= bottom
+			return inside && (px == 1 || px == w-2 || py == top || py == bottom)
+		}
+	}
+	bold, italic := c.Style.Has(AttrBold), c.Style.Has(AttrItalic)
+	pixel := func(fx, fy int) bool {
+		return fx >= 0 && fx < fontColumns && g[fy]&(1<<(fontColumns-1-fx)) != 0
+	}
+	return func(px, py int) bool {
+		fx, fy := px*fontCellColumns/w, py*fontCellRows/h
+		if fy >= fontRows {
+			return false
+		}
+		if italic {
+			fx -= (fontRows - 1 - fy) / 4
+		}
+		return pixel(fx, fy) || bold && pixel(fx-1, fy)
+	}
+}
+
+// inBand returns true if v is within the band thickness t centered on c.
+func inBand(v int, c int, t int) bool {
+	return v >= c-t/2 && v < c-t/2+t
+}
+
+// boxMask returns the pixels of a box drawing character with arms a.
+func boxMask(a arms, w int, h int) func(int, int) bool {
+	t := maxInt(1, w/8) // Thickness of a light line.
+	o := t + t/2 + 1    // Offset of the two lines of a double line.
+	// extent returns how far a line of weight reaches from its center.
+	extent := func(weight uint8) int {
+		switch weight {
+		case weightLight:
+			return t / 2
+		case weightHeavy:
+			return 3 * t / 2
+		case weightDouble:
+			return o + t/2
+		}
+		return 0
+	}
+	// arm returns true if the pixel at along, across is on an arm of weight
+	// from the center c, ca forward (towards higher along) or backward. before
+	// and after are the perpendicular arms on either side of the arm and ext
+	// is how far they reach.
+	arm := func(along, across, c, ca int, weight uint8, forward bool, before, after uint8, ext int) bool {
+		reaches := func(limit int) bool {
+			if forward {
+				return along >= c-limit
+			}
+			return along <= c+limit
+		}
+		switch weight {
+		case weightLight:
+			return inBand(across, ca, t) && reaches(ext)
+		case weightHeavy:
+			return inBand(across, ca, 3*t) && reaches(ext)
+		case weightDouble:
+			for _, side := range []struct {
+				offset int
+				arm    uint8
+			}{{-o, before}, {o, after}} {
+				if !inBand(across, ca+side.offset, t) {
+					continue
+				}
+				switch side.arm {
+				case weightNone:
+					return reaches(ext)
+				case weightDouble:
+					// Stop at the near line of the double arm.
+					return reaches(t/2 - o)
+				}
+				return reaches(0)
+			}
+		}
+		return false
+	}
+	cx, cy := w/2, h/2
+	ex := maxInt(extent(a[armUp]), extent(a[armDown]))
+	ey := maxInt(extent(a[armLeft]), extent(a[armRight]))
+	return func(px, py int) bool {
+		return arm(py, px, cy, cx, a[armUp], false, a[armLeft], a[armRight], ey) ||
+			arm(py, px, cy, cx, a[armDown], true, a[armLeft], a[armRight], ey) ||
+			arm(px, py, cx, cy, a[armLeft], false, a[armUp], a[armDown], ex) ||
+			arm(px, py, cx, cy, a[armRight], true, a[armUp], a[armDown], ex)
+	}
+}
+
+// dashedLines are the dashed box drawing characters with the arms of the line
+// and the number of dashes per cell.
+var dashedLines = map[rune]struct {
+	arms   arms
+	dashes int
+}{
+	'┄': {arms{0, weightLight, 0, weightLight}, 3},
+	'┅': {arms{0, weightHeavy, 0, weightHeavy}, 3},
+	'┆': {arms{weightLight, 0, weightLight, 0}, 3},
+	'┇': {arms{weightHeavy, 0, weightHeavy, 0}, 3},
+	'┈': {arms{0, weightLight, 0, weightLight}, 4},
+	'┉': {arms{0, weightHeavy, 0, weightHeavy}, 4},
+	'┊': {arms{weightLight, 0, weightLight, 0}, 4},
+	'┋': {arms{weightHeavy, 0, weightHeavy, 0}, 4},
+	'╌': {arms{0, weightLight, 0, weightLight}, 2},
+	'╍': {arms{0, weightHeavy, 0, weightHeavy}, 2},
+	'╎': {arms{weightLight, 0, weightLight, 0}, 2},
+	'╏': {arms{weightHeavy, 0, weightHeavy, 0}, 2},
+}
+
+// dashedMask returns the pixels of a straight line with arms a broken into
+// dashes with a gap between them.
+func dashedMask(a arms, dashes int, w int, h int) func(int, int) bool {
+	line := boxMask(a, w, h)
+	vertical := a[armUp] != weightNone
+	return func(px, py int) bool {
+		along, size := px, w
+		if vertical {
+			along, size = py, h
+		}
+		part := along * dashes % size
+		return line(px, py) && part >= size/6 && part < size-size/6
+	}
+}
+
+// diagonalMask returns the pixels of ╱, ╲ or ╳.
+func diagonalMask(r rune, w int, h int) func(int, int) bool {
+	t := maxInt(1, w/8)
+	return func(px, py int) bool {
+		y := py * w / h // Row scaled to the width of the cell.
+		rising := inBand(px, w-1-y, t+1)
+		falling := inBand(px, y, t+1)
+		switch r {
+		case '╱':
+			return rising
+		case '╲':
+			return falling
+		}
+		return rising || falling
+	}
+}
+
+// blockMask returns the pixels of a block element (U+2580-U+259F).
+func blockMask(r rune, w int, h int) func(int, int) bool {
+	for mask, block := range blockRunes {
+		if block == r {
+			return func(px, py int) bool {
+				bit := 0
+				if px >= w/2 {
+					bit++
+				}
+				if py >= h/2 {
+					bit += 2
+				}
+				return mask&(1<<bit) != 0
+			}
+		}
+	}
+	switch {
+	case r >= 0x2581 && r <= 0x2587:
+		n := int(r - 0x2580)
+		return func(px, py int) bool { return py >= h-h*n/8 }
+	case r >= 0x2589 && r <= 0x258f:
+		n := int(0x2590 - r)
+		return func(px, py int) bool { return px < w*n/8 }
+	case r == '░':
+		return func(px, py int) bool { return px%2 == 0 && py%2 == 0 }
+	case r == '▒':
+		return func(px, py int) bool { return (px+py)%2 == 0 }
+	case r == '▓':
+		return func(px, py int) bool { return px%2 != 0 || py%2 != 0 }
+	case r == '▔':
+		return func(px, py int) bool { return py < maxInt(1, h/8) }
+	}
+	// ▕ right one eighth block.
+	return func(px, py int) bool { return px >= w-maxInt(1, w/8) }
+}
+
+// brailleMask returns the pixels of a braille pattern.
+func brailleMask(r rune, w int, h int) func(int, int) bool {
+	dots := uint8(r - BrailleBlank)
+	d := maxInt(1, w/4) // Size of a dot.
+	return func(px, py int) bool {
+		column, row := px*2/w, py*4/h
+		if dots&brailleDots[row][column] == 0 {
+			return false
+		}
+		return inBand(px-column*w/2, w/4, d) && inBand(py-row*h/4, h/8, d)
+	}
+}
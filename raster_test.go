@@ -0,0 +1,138 @@
+package blox_test
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/sa6mwa/blox"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	white = color.RGBA{R: 255, G: 255, B: 255, A: 255}
+	black = color.RGBA{A: 255}
+)
+
+// lit returns the number of pixels in color c within the rectangle of cell
+// column, row of an image rasterized with cells w x h pixels large.
+func lit(img *image.RGBA, column int, row int, w int, h int, c color.RGBA) int {
+	n := 0
+	for y := row * h; y < (row+1)*h; y++ {
+		for x := column * w; x < (column+1)*w; x++ {
+			if img.RGBAAt(x, y) == c {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+func TestRasterize(t *testing.T) {
+	b := blox.New().SetColumnsAndRows(4, 3).Trim().PutText("Aé")
+	img := b.Rasterize(blox.RasterOptions{})
+	assert.Equal(t, image.Rect(0, 0, 4*12, 1*24), img.Bounds(), "final empty lines are trimmed")
+	assert.Positive(t, lit(img, 0, 0, 12, 24, black))
+	assert.Positive(t, lit(img, 1, 0, 12, 24, black))
+	assert.Zero(t, lit(img, 2, 0, 12, 24, black))
+
+	b = blox.New().SetColumnsAndRows(2, 1).PutText("\u00e9")
+	decomposed := blox.New().SetColumnsAndRows(2, 1).PutText("é")
+	assert.Equal(t, b.Rasterize(blox.RasterOptions{}).Pix, decomposed.Rasterize(blox.RasterOptions{}).Pix,
+		"combining marks are drawn like the precomposed letter")
+
+	img = blox.New().SetColumnsAndRows(1, 1).PutText("x").Rasterize(blox.RasterOptions{
+		CellWidth:  6,
+		CellHeight: 12,
+		Padding:    2,
+		Foreground: white,
+		Background: color.Gray{Y: 0},
+	})
+	assert.Equal(t, image.Rect(0, 0, 10, 16), img.Bounds())
+	assert.Equal(t, black, img.RGBAAt(0, 0))
+	expect := []string{
+		"......",
+		"......",
+		"......",
+		"......",
+		"#...#.",
+		".#.#..",
+		"..#...",
+		".#.#..",
+		"#...#.",
+		"......",
+		"......",
+		"......",
+	}
+	for y, row := range expect {
+		for x := range row {
+			want := black
+			if row[x] == '#' {
+				want = white
+			}
+			assert.Equal(t, want, img.RGBAAt(x+2, y+2), "pixel %d,%d", x, y)
+		}
+	}
+}
+
+func TestRasterizeStyles(t *testing.T) {
+	b := blox.New().SetColumnsAndRows(4, 1)
+	b.SetPen(blox.Style{Foreground: blox.RGB(255, 0, 0), Background: blox.RGB(0, 0, 255)}).PutText("A")
+	b.SetPen(blox.Style{Attributes: blox.AttrReverse}).Move(1, 0).PutText(" ")
+	b.SetPen(blox.Style{Attributes: blox.AttrUnderline}).Move(2, 0).PutText(" ")
+	b.SetPen(blox.Style{Attributes: blox.AttrBold}).Move(3, 0).PutText("l")
+	img := b.Rasterize(blox.RasterOptions{})
+	red, blue := color.RGBA{R: 255, A: 255}, color.RGBA{B: 255, A: 255}
+	assert.Positive(t, lit(img, 0, 0, 12, 24, red))
+	assert.Positive(t, lit(img, 0, 0, 12, 24, blue))
+	assert.Zero(t, lit(img, 0, 0, 12, 24, white))
+	assert.Equal(t, 12*24, lit(img, 1, 0, 12, 24, black), "reversed space")
+	assert.Equal(t, 12*2, lit(img, 2, 0, 12, 24, black), "underlined space")
+	plain := blox.New().SetColumnsAndRows(4, 1).Move(3, 0).PutText("l").Rasterize(blox.RasterOptions{})
+	assert.Greater(t, lit(img, 3, 0, 12, 24, black), lit(plain, 3, 0, 12, 24, black), "bold")
+}
+
+func TestRasterizeGraphics(t *testing.T) {
+	b := blox.New().SetColumnsAndRows(7, 2).DrawBox(0, 0, 2, 2, blox.LineLight)
+	b.Move(2, 0).PutText("█▀⠁世")
+	img := b.Rasterize(blox.RasterOptions{})
+	assert.Equal(t, black, img.RGBAAt(6, 23), "box drawing lines reach the edge of the cell")
+	assert.Equal(t, black, img.RGBAAt(11, 12))
+	assert.Equal(t, white, img.RGBAAt(5, 11))
+	assert.Equal(t, 12*24, lit(img, 2, 0, 12, 24, black))
+	assert.Equal(t, 12*12, lit(img, 3, 0, 12, 24, black))
+	assert.Equal(t, 3*3, lit(img, 4, 0, 12, 24, black))
+	assert.Positive(t, lit(img, 5, 0, 12, 24, black), "a box for a character without a glyph")
+
+	b.Move(2, 1).PutText("┄┇")
+	img = b.Rasterize(blox.RasterOptions{})
+	assert.Equal(t, white, img.RGBAAt(2*12, 24+12), "dashed lines have gaps")
+	assert.Equal(t, black, img.RGBAAt(2*12+3, 24+12))
+	assert.Equal(t, 9, lit(img, 2, 1, 12, 24, black))
+	assert.Equal(t, white, img.RGBAAt(3*12+6, 24))
+	assert.Equal(t, black, img.RGBAAt(3*12+6, 24+4))
+	assert.Equal(t, image.Rect(0, 0, 7*12, 2*24), img.Bounds())
+}
+
+func TestWritePNG(t *testing.T) {
+	var buf bytes.Buffer
+	b := blox.New().SetColumnsAndRows(10, 2).PutText("Hello")
+	assert.NoError(t, b.WritePNG(&buf, blox.RasterOptions{CellWidth: 6, CellHeight: 12, Padding: 1}))
+	img, err := png.Decode(&buf)
+	if assert.NoError(t, err) {
+		assert.Equal(t, image.Rect(0, 0, 62, 26), img.Bounds())
+	}
+	assert.Error(t, b.WritePNG(&failingWriter{}, blox.RasterOptions{}))
+}
+
+func ExampleBlox_Rasterize() {
+	b := blox.New().SetColumnsAndRows(20, 3).DrawBox(0, 0, 20, 3, blox.LineDouble)
+	b.Move(2, 1).SetForeground(blox.Green).PutText("Build passed")
+	img := b.Rasterize(blox.RasterOptions{Padding: 10})
+	fmt.Println(img.Bounds().Dx(), "x", img.Bounds().Dy())
+	// Output:
+	// 260 x 92
+}
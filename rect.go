@@ -0,0 +1,79 @@
+package blox
+
+// FillRect fills the rectangle at column x, row y that is w columns wide and h
+// rows high with the optional fill rune (space by default) in the current pen.
+// If b is in transparent mode, filling with the transparent rune leaves the
+// rectangle as it is. Everything outside of the canvas is clipped and the
+// cursor is not moved.
+func (b *Blox) FillRect(x int, y int, w int, h int, fill ...rune) *Blox {
+	f := ' '
+	if len(fill) > 0 {
+		f = fill[0]
+	}
+	if b.isTransparent([]rune{f}) {
+		return b
+	}
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			if b.cellIndex(x+i, y+j) >= 0 {
+				b.setCell(x+i, y+j, f)
+			}
+		}
+	}
+	return b
+}
+
+// ClearRect blanks the rectangle at column x, row y that is w columns wide and
+// h rows high with spaces without style, regardless of the pen and the
+// transparent mode. Everything outside of the canvas is clipped and the cursor
+// is not moved.
+func (b *Blox) ClearRect(x int, y int, w int, h int) *Blox {
+	pen, transparent := b.Pen, b.Transparent
+	b.Pen, b.Transparent = Style{}, false
+	b.FillRect(x, y, w, h)
+	b.Pen, b.Transparent = pen, transparent
+	return b
+}
+
+// CopyRect copies the rectangle at column srcX, row srcY that is w columns
+// wide and h rows high to column dstX, row dstY of the same canvas like Blit,
+// cell by cell with grapheme clusters and styles intact. The rectangles may
+// overlap. If b is in transparent mode, transparent runes are not copied.
+// Everything outside of the canvas is clipped and the cursor is not moved.
+func (b *Blox) CopyRect(srcX int, srcY int, w int, h int, dstX int, dstY int) *Blox {
+	if w <= 0 || h <= 0 {
+		return b
+	}
+	b.pasteCells(b.copyCells(srcX, srcY, w, h), dstX, dstY, true)
+	return b
+}
+
+// MoveRect moves the rectangle at column srcX, row srcY that is w columns wide
+// and h rows high to column dstX, row dstY like CopyRect, blanking what is
+// left behind like ClearRect. The cursor is not moved.
+func (b *Blox) MoveRect(srcX int, srcY int, w int, h int, dstX int, dstY int) *Blox {
+	if w <= 0 || h <= 0 {
+		return b
+	}
+	c := b.copyCells(srcX, srcY, w, h)
+	b.ClearRect(srcX, srcY, w, h)
+	b.pasteCells(c, dstX, dstY, true)
+	return b
+}
+
+// SwapRect exchanges the content of the rectangle at column x1, row y1 that
+// is w columns wide and h rows high with the rectangle of the same size at
+// column x2, row y2. Transparent runes are swapped too. If the rectangles
+// overlap, the overlapping cells end up with the content of the first
+// rectangle. Everything outside of the canvas is clipped (the other rectangle
+// gets blanks there) and the cursor is not moved.
+func (b *Blox) SwapRect(x1 int, y1 int, w int, h int, x2 int, y2 int) *Blox {
+	if w <= 0 || h <= 0 {
+		return b
+	}
+	first, second := b.copyCells(x1, y1, w, h), b.copyCells(x2, y2, w, h)
+	b.ClearRect(x1, y1, w, h).ClearRect(x2, y2, w, h)
+	b.pasteCells(second, x1, y1, false)
+	b.pasteCells(first, x2, y2, false)
+	return b
+}
@@ -0,0 +1,95 @@
+package blox_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/sa6mwa/blox"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFillRect(t *testing.T) {
+	red := blox.Style{Background: blox.Basic(1)}
+	b := blox.New().SetColumnsAndRows(5, 3).Trim().Move(1, 1)
+	b.SetPen(red).FillRect(-1, 1, 3, 5, '#').SetPen(blox.Style{})
+	assert.Equal(t, []string{"", "##", "##"}, b.Strings())
+	assert.Equal(t, 1, b.Cursor.X, "the cursor is not moved")
+	assert.Equal(t, 1, b.Cursor.Y)
+	assert.Equal(t, red, b.Cells()[2][1].Style)
+
+	b.SetTransparent(true).FillRect(0, 0, 5, 3)
+	assert.Equal(t, []string{"", "##", "##"}, b.Strings(), "filling with the transparent rune")
+
+	b.ClearRect(1, 2, 9, 9)
+	assert.Equal(t, []string{"", "##", "#"}, b.Strings())
+	assert.Equal(t, blox.Style{}, b.Styles[2*5+1])
+
+	b = blox.New().SetColumnsAndRows(4, 1).Trim().PutText("世界").FillRect(1, 0, 2, 1, '.')
+	assert.Equal(t, []string{" .."}, b.Strings(), "wide characters cut in half")
+}
+
+func TestCopyRect(t *testing.T) {
+	b := blox.New().SetColumnsAndRows(6, 3).Trim()
+	b.PutText("abc" + blox.LineBreak + "def")
+	b.CopyRect(0, 0, 3, 2, 1, 1)
+	assert.Equal(t, []string{"abc", "dabc", " def"}, b.Strings(), "overlapping rectangles")
+
+	b.CopyRect(1, 1, 3, 2, 4, 2)
+	assert.Equal(t, []string{"abc", "dabc", " defab"}, b.Strings(), "clipped at the right edge")
+
+	b = blox.New().SetColumnsAndRows(6, 1).Trim().SetTransparent(true).PutText("a b   ")
+	b.CopyRect(0, 0, 3, 1, 3, 0)
+	assert.Equal(t, []string{"a ba b"}, b.Strings())
+
+	b = blox.New().SetColumnsAndRows(6, 1).Trim().PutText("世界")
+	b.CopyRect(1, 0, 2, 1, 4, 0)
+	assert.Equal(t, []string{"世界"}, b.Strings(), "halves of wide characters are not copied")
+	b.CopyRect(0, 0, 2, 1, 5, 0)
+	assert.Equal(t, []string{"世界"}, b.Strings(), "wide character clipped at the right edge")
+
+	b = blox.New().SetColumnsAndRows(4, 1)
+	b.SetPen(blox.Style{Foreground: blox.Basic(2)}).PutText("e\u0301").SetPen(blox.Style{})
+	b.CopyRect(0, 0, 1, 1, 2, 0)
+	cells := b.Cells()
+	assert.Equal(t, []rune{0x301}, cells[0][2].Combining)
+	assert.Equal(t, blox.Basic(2), cells[0][2].Style.Foreground)
+	assert.Equal(t, blox.Style{}, b.Pen)
+}
+
+func TestMoveRect(t *testing.T) {
+	b := blox.New().SetColumnsAndRows(5, 2).Trim()
+	b.PutText("ab" + blox.LineBreak + "cd")
+	b.MoveRect(0, 0, 2, 2, 1, 0)
+	assert.Equal(t, []string{" ab", " cd"}, b.Strings())
+	b.MoveRect(1, 0, 2, 1, 4, 1)
+	assert.Equal(t, []string{"", " cd a"}, b.Strings())
+}
+
+func TestSwapRect(t *testing.T) {
+	b := blox.New().SetColumnsAndRows(6, 2).Trim()
+	b.PutText("ab  xy" + blox.LineBreak + "cd  zw")
+	b.SwapRect(0, 0, 2, 2, 4, 0)
+	assert.Equal(t, []string{"xy  ab", "zw  cd"}, b.Strings())
+	b.SwapRect(4, 0, 2, 1, 5, 1)
+	assert.Equal(t, []string{"xy  d", "zw  ca"}, b.Strings())
+
+	// The first rectangle partly off canvas, the other one gets a blank there.
+	for _, swap := range [][6]int{{5, 1, 2, 1, 4, 0}, {4, 0, 2, 1, 5, 1}} {
+		b = blox.New().SetColumnsAndRows(6, 2).Trim()
+		b.PutText("ab  xy" + blox.LineBreak + "cd  zw")
+		b.SwapRect(swap[0], swap[1], swap[2], swap[3], swap[4], swap[5])
+		assert.Equal(t, []string{"ab  w", "cd  zx"}, b.Strings())
+	}
+}
+
+func ExampleBlox_FillRect() {
+	b := blox.New().SetColumnsAndRows(12, 4).Trim()
+	b.FillRect(0, 0, 12, 4, '.').ClearRect(1, 1, 10, 2).Move(2, 1).PutText("Hello")
+	b.MoveRect(2, 1, 5, 1, 5, 2)
+	fmt.Println(b.String())
+	// Output:
+	// ............
+	// .          .
+	// .    Hello .
+	// ............
+}
@@ -0,0 +1,71 @@
+package blox
+
+// Region returns a view of the rectangle at column x, row y of b that is w
+// columns wide and h rows high. The view is a Blox of its own where 0,0 is the
+// upper left hand corner of the rectangle and Columns/Rows is the size of it,
+// but it has no canvas of its own: everything written to it lands in the
+// canvas of b and everything outside of the rectangle (or outside of b) is
+// clipped. The view starts with the pen, line spacing, transparency, strict
+// mode, scrollback limit and trim settings of b and shares its error (see
+// Err). Rows scrolled out of the view go to the Scrollback of the view.
+//
+// A Region allows a component to render into its own box with PutText,
+// DrawSeparator, DrawSplit and all other functions without knowing where on
+// the canvas the box is. Resizing a Region only changes the size of the view.
+func (b *Blox) Region(x int, y int, w int, h int) *Blox {
+	if w < 0 {
+		w = 0
+	}
+	if h < 0 {
+		h = 0
+	}
+	r := &Blox{
+		Columns:             w,
+		Rows:                h,
+		LineSpacing:         b.LineSpacing,
+		TrimRightSpaces:     b.TrimRightSpaces,
+		TrimFinalEmptyLines: b.TrimFinalEmptyLines,
+		Transparent:         b.Transparent,
+		TransparentRune:     b.TransparentRune,
+		Pen:                 b.Pen,
+		Strict:              b.Strict,
+		ScrollbackLimit:     b.ScrollbackLimit,
+		parent:              b,
+		offsetX:             x + b.originX,
+		offsetY:             y + b.originY,
+	}
+	return r.Move(0, 0)
+}
+
+// Parent returns the Blox a Region was created from or nil if b is not a
+// Region. Useful to continue a chain on the parent after drawing in a region.
+func (b *Blox) Parent() *Blox {
+	return b.parent
+}
+
+// IsRegion returns true if b is a view of another Blox (see Region).
+func (b *Blox) IsRegion() bool {
+	return b.parent != nil
+}
+
+// root returns the Blox owning the canvas storage, b itself unless b is a
+// Region.
+func (b *Blox) root() *Blox {
+	for b.parent != nil {
+		b = b.parent
+	}
+	return b
+}
+
+// rootPosition translates column x, row y of b (relative to the origin) to
+// the Blox owning the canvas storage.
+func (b *Blox) rootPosition(x int, y int) (*Blox, int, int) {
+	x += b.originX
+	y += b.originY
+	for b.parent != nil {
+		x += b.offsetX
+		y += b.offsetY
+		b = b.parent
+	}
+	return b, x, y
+}
@@ -0,0 +1,60 @@
+package blox_test
+
+import (
+	"testing"
+
+	"github.com/sa6mwa/blox"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegion(t *testing.T) {
+	b := blox.New().SetColumnsAndRows(20, 6).Trim()
+	r := b.Region(5, 1, 10, 4)
+	assert.Equal(t, 10, r.Columns)
+	assert.Equal(t, 4, r.Rows)
+	assert.True(t, r.IsRegion())
+	assert.False(t, b.IsRegion())
+	assert.Equal(t, b, r.Parent())
+
+	r.PutText("Title").DrawSeparator().PutText("This line is clipped").MoveX(4).DrawSplit()
+
+	expect := blox.LineBreak +
+		"     Titl|" + blox.LineBreak +
+		"     ----|-----" + blox.LineBreak +
+		"     This|line" + blox.LineBreak +
+		"         |" + blox.LineBreak
+	assert.Equal(t, expect, b.String())
+
+	expect = "Titl|" + blox.LineBreak +
+		"----|-----" + blox.LineBreak +
+		"This|line" + blox.LineBreak +
+		"    |" + blox.LineBreak
+	assert.Equal(t, expect, r.String())
+}
+
+func TestRegionClipsToParent(t *testing.T) {
+	b := blox.New().SetColumnsAndRows(6, 2).Trim()
+	r := b.Region(3, 1, 6, 3)
+	r.PutText("abcdef" + blox.LineBreak + "ghi")
+	assert.Equal(t, blox.LineBreak+"   abc"+blox.LineBreak, b.String())
+	assert.Equal(t, "abc"+blox.LineBreak, r.String())
+
+	// Regions of regions are relative to their parent.
+	inner := b.Region(1, 0, 4, 2).Region(1, 1, 2, 1)
+	inner.SetForeground(blox.Red).PutText("XYZ")
+	assert.Equal(t, blox.LineBreak+"  XYbc"+blox.LineBreak, b.String())
+	assert.Equal(t, blox.Red, b.StyleAt(2, 1).Foreground)
+
+	// Resizing a region does not touch the parent.
+	inner.SetColumnsAndRows(1, 1)
+	assert.Len(t, b.Canvas, 12)
+}
+
+func TestRegionWideCharacters(t *testing.T) {
+	b := blox.New().SetColumnsAndRows(6, 1).Trim().PutText("日本語")
+	b.Region(3, 0, 3, 1).PutChar('x')
+	assert.Equal(t, "日 x語"+blox.LineBreak, b.String())
+	// Half a wide character does not fit at the edge of the region.
+	b.Region(2, 0, 3, 1).PutText("ab東")
+	assert.Equal(t, "日ab"+blox.LineBreak, b.String())
+}
@@ -0,0 +1,156 @@
+package blox
+
+// Anchor decides which part of the content stays in place when the canvas is
+// resized with Resize, for example AnchorBottomRight keeps the bottom right
+// hand corner where it is relative to the new size, adding or cropping
+// columns on the left and rows at the top.
+type Anchor uint8
+
+const (
+	AnchorTopLeft Anchor = iota
+	AnchorTop
+	AnchorTopRight
+	AnchorLeft
+	AnchorCenter
+	AnchorRight
+	AnchorBottomLeft
+	AnchorBottom
+	AnchorBottomRight
+)
+
+// offset returns how far content moves (columns, rows) when the canvas is
+// resized from oldColumns and oldRows to columns and rows.
+func (a Anchor) offset(oldColumns int, oldRows int, columns int, rows int) (int, int) {
+	var dx, dy int
+	switch a {
+	case AnchorTop, AnchorCenter, AnchorBottom:
+		dx = (columns - oldColumns) / 2
+	case AnchorTopRight, AnchorRight, AnchorBottomRight:
+		dx = columns - oldColumns
+	}
+	switch a {
+	case AnchorLeft, AnchorCenter, AnchorRight:
+		dy = (rows - oldRows) / 2
+	case AnchorBottomLeft, AnchorBottom, AnchorBottomRight:
+		dy = rows - oldRows
+	}
+	return dx, dy
+}
+
+// Resize changes the size of the canvas to columns and rows keeping the 2D
+// layout of the content. anchor decides where the content stays: new columns
+// and rows are added (or cropped when shrinking) on the opposite side(s) of
+// the anchor, both sides for the center. New cells are filled with the
+// optional fill rune (space by default). A wide character cut in half by the
+// cropping is replaced by fill. The cursor, saved cursor positions and
+// origins (see PushOrigin) move with the content. Layers are resized the same
+// way, filled with their transparent rune. Resizing a Region only changes the
+// size of the view.
+//
+//	b.Resize(termWidth, b.Rows, blox.AnchorTop) // Keep the content centered.
+func (b *Blox) Resize(columns int, rows int, anchor Anchor, fill ...rune) *Blox {
+	if columns < 0 {
+		columns = 0
+	}
+	if rows < 0 {
+		rows = 0
+	}
+	if b.parent != nil {
+		return b.SetColumnsAndRows(columns, rows)
+	}
+	f := ' '
+	if len(fill) > 0 {
+		f = fill[0]
+	}
+	dx, dy := anchor.offset(b.Columns, b.Rows, columns, rows)
+	b.relayout(columns, rows, dx, dy, f)
+	for _, l := range b.Layers {
+		l.relayout(columns, rows, dx, dy, l.transparentRune())
+	}
+	// Origins pushed with PushOrigin move with the content, positions relative
+	// to them are unchanged.
+	cx, cy := dx, dy
+	if len(b.origins) > 0 {
+		b.originX += dx
+		b.originY += dy
+		for i := 1; i < len(b.origins); i++ {
+			b.origins[i].X += dx
+			b.origins[i].Y += dy
+		}
+		cx, cy = 0, 0
+	}
+	for i := range b.CursorStack {
+		b.CursorStack[i].X += cx
+		b.CursorStack[i].Y += cy
+	}
+	return b.Move(b.Cursor.X+cx, b.Cursor.Y+cy)
+}
+
+// resize changes the size of the canvas and its layers keeping the content
+// at the same column and row, content outside of the new size is dropped.
+func (b *Blox) resize(columns int, rows int) {
+	b.relayout(columns, rows, 0, 0, ' ')
+	b.resizeLayers()
+	b.Move(b.Cursor.X, b.Cursor.Y)
+}
+
+// relayout resizes the canvas (not its layers) to columns and rows, moving
+// the content dx columns right and dy rows down. New cells are filled with
+// fill. The cursor is not moved.
+func (b *Blox) relayout(columns int, rows int, dx int, dy int, fill rune) {
+	oldColumns, oldRows := b.canvasColumns, b.canvasRows
+	if fill == ' ' && (len(b.Canvas) == 0 || oldColumns == 0 || columns == oldColumns && dx == 0 && dy == 0) {
+		// Rows are added to or removed from the end of the flat canvas.
+		b.Columns = columns
+		b.Rows = rows
+		b.resizeCanvas()
+		return
+	}
+	canvas := make([]rune, columns*rows, maxInt(columns*rows, cap(b.Canvas)))
+	styles := make([]Style, columns*rows)
+	var combining map[int][]rune
+	for i := range canvas {
+		canvas[i] = fill
+	}
+	for y := 0; y < rows; y++ {
+		oy := y - dy
+		if oy < 0 || oy >= oldRows {
+			continue
+		}
+		for x := 0; x < columns; x++ {
+			ox := x - dx
+			src := oy*oldColumns + ox
+			if ox < 0 || ox >= oldColumns || src >= len(b.Canvas) {
+				continue
+			}
+			dst := y*columns + x
+			r := b.Canvas[src]
+			switch {
+			case r == ContinuationCell && x == 0:
+				// The first half of the wide character was cut off.
+				continue
+			case x == columns-1 && ox+1 < oldColumns && src+1 < len(b.Canvas) &&
+				b.Canvas[src+1] == ContinuationCell:
+				// The second half of the wide character was cut off.
+				continue
+			}
+			canvas[dst] = r
+			if src < len(b.Styles) {
+				styles[dst] = b.Styles[src]
+			}
+			if marks, ok := b.Combining[src]; ok {
+				if combining == nil {
+					combining = make(map[int][]rune)
+				}
+				combining[dst] = marks
+			}
+		}
+	}
+	b.Columns = columns
+	b.Rows = rows
+	b.Canvas = canvas
+	b.Styles = styles
+	b.Combining = combining
+	b.canvasColumns = columns
+	b.canvasRows = rows
+}
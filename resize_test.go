@@ -0,0 +1,85 @@
+package blox_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/sa6mwa/blox"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetColumnsKeepsLayout(t *testing.T) {
+	b := blox.New().SetColumnsAndRows(4, 3).SetTrim(false).PutText("abcd" + blox.LineBreak + "efgh" + blox.LineBreak + "ijkl")
+	b.SetColumns(6)
+	assert.Equal(t, []string{"abcd  ", "efgh  ", "ijkl  "}, b.Strings())
+	b.SetColumns(2)
+	assert.Equal(t, []string{"ab", "ef", "ij"}, b.Strings())
+	b.SetColumnsAndRows(3, 2)
+	assert.Equal(t, []string{"ab ", "ef "}, b.Strings())
+}
+
+func TestResize(t *testing.T) {
+	text := "abc" + blox.LineBreak + "def" + blox.LineBreak + "ghi"
+	cases := []struct {
+		columns, rows int
+		anchor        blox.Anchor
+		expect        []string
+	}{
+		{5, 4, blox.AnchorTopLeft, []string{"abc..", "def..", "ghi..", "....."}},
+		{5, 5, blox.AnchorCenter, []string{".....", ".abc.", ".def.", ".ghi.", "....."}},
+		{4, 4, blox.AnchorBottomRight, []string{"....", ".abc", ".def", ".ghi"}},
+		{2, 2, blox.AnchorBottomRight, []string{"ef", "hi"}},
+		{1, 1, blox.AnchorCenter, []string{"e"}},
+		{3, 1, blox.AnchorBottom, []string{"ghi"}},
+		{5, 3, blox.AnchorRight, []string{"..abc", "..def", "..ghi"}},
+	}
+	for i, tc := range cases {
+		b := blox.New().SetColumnsAndRows(3, 3).PutText(text)
+		b.Resize(tc.columns, tc.rows, tc.anchor, '.')
+		assert.Equal(t, tc.columns, b.Columns, "case %d", i)
+		assert.Equal(t, tc.rows, b.Rows, "case %d", i)
+		assert.Equal(t, tc.expect, b.Strings(), "case %d", i)
+	}
+}
+
+func TestResizeCursorStylesAndLayers(t *testing.T) {
+	b := blox.New().SetColumnsAndRows(4, 2).Trim()
+	b.SetForeground(blox.Green).PutText("\u00e9\u4e16").ResetPen().Move(1, 1).PushPos().Move(2, 1)
+	b.Layer("top").Move(3, 0).PutChar('T')
+	b.Resize(6, 3, blox.AnchorBottomRight)
+	assert.Equal(t, []string{"", "  \u00e9\u4e16T"}, b.Strings())
+	assert.Equal(t, blox.Green, b.StyleAt(2, 1).Foreground)
+	assert.Equal(t, 4, b.Cursor.X)
+	assert.Equal(t, 2, b.Cursor.Y)
+	b.PopPos()
+	assert.Equal(t, 3, b.Cursor.X)
+
+	// The wide character is cut in half and replaced by the fill rune.
+	b.Resize(4, 3, blox.AnchorTopLeft, '#')
+	assert.Equal(t, []string{"", "  \u00e9#"}, b.Strings())
+	b.Resize(3, 2, blox.AnchorRight)
+	assert.Equal(t, []string{"", " \u00e9#"}, b.Strings())
+}
+
+func TestResizeOrigin(t *testing.T) {
+	b := blox.New().SetColumnsAndRows(6, 3).Trim()
+	b.PushOrigin(1, 1).PutText("ab").PushOrigin(1, 0).Move(0, 1)
+	b.Resize(8, 4, blox.AnchorBottomRight)
+	x, y := b.Origin()
+	assert.Equal(t, 4, x)
+	assert.Equal(t, 2, y)
+	assert.Equal(t, 0, b.Cursor.X, "the cursor is relative to the origin")
+	assert.Equal(t, 1, b.Cursor.Y)
+	b.Move(1, 0).PutText("c").PopOrigin().Move(0, 1).PutText("d").PopOrigin().Move(0, 0).PutText("e")
+	assert.Equal(t, []string{"e", "", "   abc", "   d"}, b.Strings(), "the outermost origin stays at 0,0")
+}
+
+func ExampleBlox_Resize() {
+	b := blox.New().SetColumnsAndRows(7, 3).Trim().DrawBox(0, 0, 7, 3, blox.LineASCII).Move(2, 1).PutText("box")
+	b.Resize(11, 3, blox.AnchorCenter, '.')
+	fmt.Print(b.String())
+	// Output:
+	// ..+-----+..
+	// ..| box |..
+	// ..+-----+..
+}
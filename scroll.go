@@ -0,0 +1,105 @@
+package blox
+
+// SetScrollback enables a scrollback buffer keeping the last lines rows
+// scrolled out of the top by ScrollUp, Scroll and ScrollRect (and by a
+// Terminal writing past the bottom row). 0 disables the buffer and discards
+// its content.
+func (b *Blox) SetScrollback(lines int) *Blox {
+	b.ScrollbackLimit = lines
+	if lines <= 0 {
+		b.Scrollback = nil
+	} else if len(b.Scrollback) > lines {
+		b.Scrollback = b.Scrollback[len(b.Scrollback)-lines:]
+	}
+	return b
+}
+
+// ScrollUp moves the content of the canvas up n rows, see ScrollRect.
+func (b *Blox) ScrollUp(n int, fill ...rune) *Blox {
+	return b.Scroll(0, -n, fill...)
+}
+
+// ScrollDown moves the content of the canvas down n rows, see ScrollRect.
+func (b *Blox) ScrollDown(n int, fill ...rune) *Blox {
+	return b.Scroll(0, n, fill...)
+}
+
+// ScrollLeft moves the content of the canvas left n columns, see ScrollRect.
+func (b *Blox) ScrollLeft(n int, fill ...rune) *Blox {
+	return b.Scroll(-n, 0, fill...)
+}
+
+// ScrollRight moves the content of the canvas right n columns, see
+// ScrollRect.
+func (b *Blox) ScrollRight(n int, fill ...rune) *Blox {
+	return b.Scroll(n, 0, fill...)
+}
+
+// Scroll moves the content of the entire canvas dx columns right (left if
+// negative) and dy rows down (up if negative), see ScrollRect.
+func (b *Blox) Scroll(dx int, dy int, fill ...rune) *Blox {
+	return b.ScrollRect(-b.originX, -b.originY, b.Columns, b.Rows, dx, dy, fill...)
+}
+
+// ScrollRect moves the content of the rectangle at column x, row y that is w
+// columns wide and h rows high dx columns right (left if negative) and dy rows
+// down (up if negative). Content moved out of the rectangle is dropped and the
+// cells left behind are filled with the optional fill rune (space by default)
+// in the current pen. Content outside of the rectangle is not touched, a wide
+// character cut in half by the edge of the rectangle is replaced by a space.
+// When scrolling up with a scrollback buffer (see SetScrollback), the rows
+// scrolled out of the top of the rectangle are appended to Scrollback. Layers
+// are not scrolled and the cursor is not moved.
+func (b *Blox) ScrollRect(x int, y int, w int, h int, dx int, dy int, fill ...rune) *Blox {
+	if w <= 0 || h <= 0 {
+		return b
+	}
+	f := ' '
+	if len(fill) > 0 {
+		f = fill[0]
+	}
+	c := b.copyCells(x, y, w, h)
+	if dy < 0 && b.ScrollbackLimit > 0 {
+		for j := 0; j < -dy && j < h; j++ {
+			b.saveScrollback(c.cells[j*w : j*w+w])
+		}
+	}
+	pen := b.Pen
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			if b.cellIndex(x+i, y+j) < 0 {
+				continue
+			}
+			sx, sy := i-dx, j-dy
+			if sx >= 0 && sy >= 0 && sx < w && sy < h {
+				if cell, ok := c.cell(sx, sy, i == 0, i+1 == w); ok {
+					b.putCell(x+i, y+j, cell)
+					continue
+				}
+			}
+			b.Pen = pen
+			b.setCell(x+i, y+j, f)
+		}
+	}
+	b.Pen = pen
+	return b
+}
+
+// saveScrollback appends a row of cells (continuation cells removed) to the
+// scrollback buffer, dropping the oldest rows beyond ScrollbackLimit.
+func (b *Blox) saveScrollback(row []Cell) {
+	line := make([]Cell, 0, len(row))
+	for _, c := range row {
+		switch c.Rune {
+		case ContinuationCell:
+			continue
+		case 0:
+			c = Cell{Rune: ' '}
+		}
+		line = append(line, c)
+	}
+	b.Scrollback = append(b.Scrollback, line)
+	if len(b.Scrollback) > b.ScrollbackLimit {
+		b.Scrollback = b.Scrollback[len(b.Scrollback)-b.ScrollbackLimit:]
+	}
+}
@@ -0,0 +1,95 @@
+package blox_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/sa6mwa/blox"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScroll(t *testing.T) {
+	lines := "abcd" + blox.LineBreak + "efgh" + blox.LineBreak + "ijkl"
+	b := blox.New().SetColumnsAndRows(4, 3).Trim().PutText(lines)
+	b.ScrollUp(1)
+	assert.Equal(t, []string{"efgh", "ijkl"}, b.Strings())
+	b.ScrollDown(2, '.')
+	assert.Equal(t, []string{"....", "....", "efgh"}, b.Strings())
+	assert.Equal(t, 0, b.Cursor.X, "the cursor is not moved")
+	assert.Equal(t, 2, b.Cursor.Y)
+
+	b = blox.New().SetColumnsAndRows(4, 3).Trim().PutText(lines)
+	b.ScrollLeft(1, '-').ScrollRight(2)
+	assert.Equal(t, []string{"  bc", "  fg", "  jk"}, b.Strings())
+
+	b = blox.New().SetColumnsAndRows(4, 3).Trim().PutText(lines).Scroll(1, -1)
+	assert.Equal(t, []string{" efg", " ijk"}, b.Strings())
+
+	b = blox.New().SetColumnsAndRows(4, 3).Trim().PutText(lines).ScrollUp(5)
+	assert.Empty(t, b.Strings())
+}
+
+func TestScrollRect(t *testing.T) {
+	b := blox.New().SetColumnsAndRows(5, 3).Trim()
+	b.PutText("abcde" + blox.LineBreak + "fghij" + blox.LineBreak + "klmno")
+	b.ScrollRect(1, 0, 3, 3, 0, -1)
+	assert.Equal(t, []string{"aghie", "flmnj", "k   o"}, b.Strings())
+	b.ScrollRect(1, 1, 3, 1, -1, 0, '*')
+	assert.Equal(t, []string{"aghie", "fmn*j", "k   o"}, b.Strings())
+
+	b = blox.New().SetColumnsAndRows(6, 1).Trim().PutText("a世界b")
+	b.ScrollRect(0, 0, 4, 1, -1, 0)
+	assert.Equal(t, []string{"世   b"}, b.Strings(), "wide character scrolled out at the right edge")
+	b = blox.New().SetColumnsAndRows(6, 1).Trim().PutText("a世界b")
+	b.ScrollRect(0, 0, 4, 1, 1, 0)
+	assert.Equal(t, []string{" a世 b"}, b.Strings(), "wide character cut by the edge of the rectangle")
+
+	b = blox.New().SetColumnsAndRows(3, 2).Trim()
+	b.SetPen(blox.Style{Foreground: blox.Basic(1)}).PutText("é")
+	b.SetPen(blox.Style{}).ScrollRight(1)
+	cells := b.Cells()
+	assert.Equal(t, "é", string(append([]rune{cells[0][1].Rune}, cells[0][1].Combining...)))
+	assert.Equal(t, blox.Basic(1), cells[0][1].Style.Foreground)
+}
+
+func TestScrollback(t *testing.T) {
+	b := blox.New().SetColumnsAndRows(4, 2).Trim().SetScrollback(2)
+	for _, line := range []string{"one", "two", "tre", "fyr"} {
+		b.ScrollUp(1).Move(0, 1).PutText(line)
+	}
+	assert.Equal(t, []string{"tre", "fyr"}, b.Strings())
+	if assert.Len(t, b.Scrollback, 2) {
+		assert.Equal(t, "one ", cellsString(b.Scrollback[0]))
+		assert.Equal(t, "two ", cellsString(b.Scrollback[1]))
+	}
+	b.SetScrollback(1)
+	assert.Len(t, b.Scrollback, 1)
+	b.SetScrollback(0).ScrollUp(1)
+	assert.Nil(t, b.Scrollback)
+
+	b = blox.New().SetColumnsAndRows(6, 3).SetScrollback(10)
+	fmt.Fprint(b.Region(1, 0, 4, 2).SetScrollback(10).Terminal(), "ab\ncd\nef")
+	assert.Nil(t, b.Scrollback, "a Region has its own scrollback buffer")
+}
+
+func cellsString(cells []blox.Cell) string {
+	var s []rune
+	for _, c := range cells {
+		s = append(s, c.Rune)
+		s = append(s, c.Combining...)
+	}
+	return string(s)
+}
+
+func ExampleBlox_ScrollUp() {
+	b := blox.New().SetColumnsAndRows(10, 3).Trim().SetScrollback(100)
+	log := b.Region(0, 0, 10, 3)
+	for i := 1; i <= 5; i++ {
+		log.ScrollUp(1).Move(0, 2).PutText(fmt.Sprintf("line %d", i))
+	}
+	fmt.Println(b.String())
+	// Output:
+	// line 3
+	// line 4
+	// line 5
+}
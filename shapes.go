@@ -0,0 +1,246 @@
+package blox
+
+import (
+	"image"
+	"math"
+)
+
+// plot writes r with the current pen to column x, row y unless r is
+// transparent, see SetTransparent.
+func (b *Blox) plot(x int, y int, r rune) {
+	if b.isTransparent([]rune{r}) {
+		return
+	}
+	b.setCell(x, y, r)
+}
+
+// slopeRune returns the ASCII character closest to the direction dx, dy
+// (columns right and rows down): -, |, / or \. Cells are assumed to be twice
+// as tall as wide.
+func slopeRune(dx float64, dy float64) rune {
+	w, h := math.Abs(dx), 2*math.Abs(dy)
+	switch {
+	case h < w*math.Tan(math.Pi/8):
+		return '-'
+	case h > w*math.Tan(3*math.Pi/8):
+		return '|'
+	case dx*dy < 0:
+		return '/'
+	}
+	return '\\'
+}
+
+// linePoints returns the cells of the line from x0, y0 to x1, y1 (Bresenham's
+// line algorithm).
+func linePoints(x0 int, y0 int, x1 int, y1 int) []image.Point {
+	dx, dy := x1-x0, y1-y0
+	if dx < 0 {
+		dx = -dx
+	}
+	if dy < 0 {
+		dy = -dy
+	}
+	sx, sy := 1, 1
+	if x0 > x1 {
+		sx = -1
+	}
+	if y0 > y1 {
+		sy = -1
+	}
+	points := make([]image.Point, 0, maxInt(dx, dy)+1)
+	e := dx - dy
+	for {
+		points = append(points, image.Pt(x0, y0))
+		if x0 == x1 && y0 == y1 {
+			return points
+		}
+		e2 := 2 * e
+		if e2 > -dy {
+			e -= dy
+			x0 += sx
+		}
+		if e2 < dx {
+			e += dx
+			y0 += sy
+		}
+	}
+}
+
+// DrawLine draws a straight line from column x0, row y0 to column x1, row y1.
+// Without the optional char the line is drawn with -, |, / or \ depending on
+// its slope, so a diagram can be drawn with any angle between its boxes. The
+// cursor is not moved.
+//
+//	b.DrawLine(0, 0, 6, 3)
+//
+//	\\
+//	  \\
+//	    \\
+//	      \
+func (b *Blox) DrawLine(x0 int, y0 int, x1 int, y1 int, char ...rune) *Blox {
+	r := slopeRune(float64(x1-x0), float64(y1-y0))
+	if len(char) > 0 {
+		r = char[0]
+	}
+	for _, p := range linePoints(x0, y0, x1, y1) {
+		b.plot(p.X, p.Y, r)
+	}
+	return b
+}
+
+// DrawPolyline draws lines between consecutive points like DrawLine. The
+// cursor is not moved.
+func (b *Blox) DrawPolyline(points []image.Point, char ...rune) *Blox {
+	for i := 1; i < len(points); i++ {
+		b.DrawLine(points[i-1].X, points[i-1].Y, points[i].X, points[i].Y, char...)
+	}
+	if len(points) == 1 {
+		b.DrawLine(points[0].X, points[0].Y, points[0].X, points[0].Y, char...)
+	}
+	return b
+}
+
+// DrawPolygon draws the outline of the polygon with the corners points like
+// DrawPolyline, closing it with a line from the last point to the first. The
+// cursor is not moved.
+func (b *Blox) DrawPolygon(points []image.Point, char ...rune) *Blox {
+	b.DrawPolyline(points, char...)
+	if len(points) > 2 {
+		last := points[len(points)-1]
+		b.DrawLine(last.X, last.Y, points[0].X, points[0].Y, char...)
+	}
+	return b
+}
+
+// FillPolygon fills the polygon with the corners points, including its
+// outline, with the optional fill rune (# by default) in the current pen.
+// Cells inside are found with the even-odd rule, so a self-intersecting
+// polygon has holes. The cursor is not moved.
+func (b *Blox) FillPolygon(points []image.Point, fill ...rune) *Blox {
+	f := '#'
+	if len(fill) > 0 {
+		f = fill[0]
+	}
+	if len(points) == 0 {
+		return b
+	}
+	bounds := image.Rectangle{Min: points[0], Max: points[0]}
+	for _, p := range points[1:] {
+		bounds.Min.X, bounds.Max.X = minInt(bounds.Min.X, p.X), maxInt(bounds.Max.X, p.X)
+		bounds.Min.Y, bounds.Max.Y = minInt(bounds.Min.Y, p.Y), maxInt(bounds.Max.Y, p.Y)
+	}
+	for y := bounds.Min.Y; y <= bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x <= bounds.Max.X; x++ {
+			if insidePolygon(points, float64(x), float64(y)) {
+				b.plot(x, y, f)
+			}
+		}
+	}
+	return b.DrawPolygon(points, f)
+}
+
+// insidePolygon returns true if x, y is inside the polygon according to the
+// even-odd rule.
+func insidePolygon(points []image.Point, x float64, y float64) bool {
+	inside := false
+	for i, j := 0, len(points)-1; i < len(points); j, i = i, i+1 {
+		xi, yi := float64(points[i].X), float64(points[i].Y)
+		xj, yj := float64(points[j].X), float64(points[j].Y)
+		if (yi > y) != (yj > y) && x < (xj-xi)*(y-yi)/(yj-yi)+xi {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// DrawCircle draws a circle centered at column x, row y that is r rows high
+// above and below the center and 2*r columns wide on either side, which looks
+// round with cells twice as tall as wide. See DrawEllipse.
+func (b *Blox) DrawCircle(x int, y int, r int, char ...rune) *Blox {
+	return b.DrawEllipse(x, y, 2*r, r, char...)
+}
+
+// DrawEllipse draws an ellipse centered at column x, row y with the horizontal
+// radius rx columns and the vertical radius ry rows. Without the optional
+// char the outline is drawn with -, |, / and \ following its curve. The cursor
+// is not moved.
+//
+//	b.DrawEllipse(6, 2, 6, 2)
+//
+//	   -------
+//	 //       \\
+//	|           |
+//	 \\       //
+//	   -------
+func (b *Blox) DrawEllipse(x int, y int, rx int, ry int, char ...rune) *Blox {
+	return b.DrawArc(x, y, rx, ry, 0, 360, char...)
+}
+
+// DrawArc draws the part of the ellipse centered at column x, row y with the
+// radii rx and ry (see DrawEllipse) from angle start to angle end in degrees,
+// counterclockwise with 0 at the right (3 o'clock) and 90 at the top. The
+// cursor is not moved.
+func (b *Blox) DrawArc(x int, y int, rx int, ry int, start float64, end float64, char ...rune) *Blox {
+	if rx < 0 || ry < 0 {
+		return b
+	}
+	if rx == 0 || ry == 0 {
+		return b.DrawLine(x-rx, y-ry, x+rx, y+ry, char...)
+	}
+	for end < start {
+		end += 360
+	}
+	full := end-start >= 360
+	from, to := start*math.Pi/180, end*math.Pi/180
+	fx, fy := float64(rx), float64(ry)
+	// The outline is the cells closest to the curve on every row and every
+	// column, which keeps it connected and symmetric.
+	points := make(map[image.Point]bool)
+	for j := -ry; j <= ry; j++ {
+		i := int(math.Round(fx * math.Sqrt(1-float64(j*j)/(fy*fy))))
+		points[image.Pt(i, j)], points[image.Pt(-i, j)] = true, true
+	}
+	for i := -rx; i <= rx; i++ {
+		j := int(math.Round(fy * math.Sqrt(1-float64(i*i)/(fx*fx))))
+		points[image.Pt(i, j)], points[image.Pt(i, -j)] = true, true
+	}
+	for p := range points {
+		if !full {
+			// Angle of the point on a circle scaled to the ellipse.
+			a := math.Atan2(-float64(p.Y)/math.Max(fy, 1), float64(p.X)/math.Max(fx, 1))
+			for a < from {
+				a += 2 * math.Pi
+			}
+			if a > to {
+				continue
+			}
+		}
+		r := slopeRune(float64(p.Y)*fx*fx, -float64(p.X)*fy*fy)
+		if len(char) > 0 {
+			r = char[0]
+		}
+		b.plot(x+p.X, y+p.Y, r)
+	}
+	return b
+}
+
+// FillEllipse fills the ellipse centered at column x, row y with the radii rx
+// and ry (see DrawEllipse), including its outline, with the optional fill rune
+// (# by default) in the current pen. The cursor is not moved.
+func (b *Blox) FillEllipse(x int, y int, rx int, ry int, fill ...rune) *Blox {
+	if rx < 0 || ry < 0 {
+		return b
+	}
+	f := '#'
+	if len(fill) > 0 {
+		f = fill[0]
+	}
+	for j := -ry; j <= ry; j++ {
+		for i := -rx; i <= rx; i++ {
+			if i*i*ry*ry+j*j*rx*rx <= rx*rx*ry*ry {
+				b.plot(x+i, y+j, f)
+			}
+		}
+	}
+	return b.DrawEllipse(x, y, rx, ry, f)
+}
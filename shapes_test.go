@@ -0,0 +1,97 @@
+package blox_test
+
+import (
+	"fmt"
+	"image"
+	"testing"
+
+	"github.com/sa6mwa/blox"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDrawLine(t *testing.T) {
+	b := blox.New().SetColumnsAndRows(8, 4).Trim().Move(1, 1)
+	b.DrawLine(0, 0, 3, 3).DrawLine(7, 0, 4, 3).DrawLine(1, 0, 6, 0).DrawLine(7, 1, 7, 3)
+	expect := []string{
+		"\\------/",
+		" \\    /|",
+		"  \\  / |",
+		"   \\/  |",
+	}
+	assert.Equal(t, expect, b.Strings())
+	assert.Equal(t, 1, b.Cursor.X, "the cursor is not moved")
+	assert.Equal(t, 1, b.Cursor.Y)
+
+	b = blox.New().SetColumnsAndRows(8, 2).Trim().DrawLine(7, 1, 0, 0, '*')
+	assert.Equal(t, []string{"****", "    ****"}, b.Strings())
+
+	b = blox.New().SetColumnsAndRows(4, 2).Trim().DrawLine(-2, 0, 5, 0)
+	assert.Equal(t, []string{"----"}, b.Strings(), "clipped")
+
+	b = blox.New().SetColumnsAndRows(4, 2).Trim().SetTransparent(true).DrawLine(0, 0, 3, 0, ' ')
+	assert.Empty(t, b.Strings())
+}
+
+func TestDrawPolygon(t *testing.T) {
+	triangle := []image.Point{{0, 3}, {3, 0}, {6, 3}}
+	b := blox.New().SetColumnsAndRows(7, 4).Trim()
+	b.DrawPolyline(triangle)
+	expect := []string{
+		"   \\",
+		"  / \\",
+		" /   \\",
+		"/     \\",
+	}
+	assert.Equal(t, expect, b.Strings())
+
+	b.DrawPolygon(triangle)
+	expect[3] = "-------"
+	assert.Equal(t, expect, b.Strings())
+
+	b = blox.New().SetColumnsAndRows(7, 4).Trim().FillPolygon(triangle)
+	assert.Equal(t, []string{"   #", "  ###", " #####", "#######"}, b.Strings())
+
+	bowtie := []image.Point{{0, 0}, {4, 4}, {4, 0}, {0, 4}}
+	b = blox.New().SetColumnsAndRows(5, 5).Trim().FillPolygon(bowtie, 'o')
+	assert.Equal(t, []string{"o   o", "oo oo", "ooooo", "oo oo", "o   o"}, b.Strings())
+}
+
+func TestDrawEllipse(t *testing.T) {
+	b := blox.New().SetColumnsAndRows(7, 3).Trim().DrawEllipse(3, 1, 3, 1, 'o')
+	assert.Equal(t, []string{" ooooo", "o     o", " ooooo"}, b.Strings())
+
+	b = blox.New().SetColumnsAndRows(9, 5).Trim().DrawCircle(4, 2, 2)
+	expect := []string{
+		"  /---\\",
+		" /     \\",
+		"|       |",
+		" \\     /",
+		"  \\---/",
+	}
+	assert.Equal(t, expect, b.Strings())
+
+	b = blox.New().SetColumnsAndRows(9, 5).Trim().DrawArc(4, 2, 4, 2, 0, 90)
+	assert.Equal(t, []string{"    --\\", "       \\", "        |"}, b.Strings())
+	b = blox.New().SetColumnsAndRows(9, 5).Trim().DrawArc(4, 2, 4, 2, 180, -90)
+	assert.Equal(t, []string{"", "", "|", " \\", "  \\--"}, b.Strings())
+
+	b = blox.New().SetColumnsAndRows(7, 3).Trim().DrawEllipse(3, 1, 3, 0)
+	assert.Equal(t, []string{"", "-------"}, b.Strings(), "flat ellipse")
+
+	b = blox.New().SetColumnsAndRows(7, 3).Trim().FillEllipse(3, 1, 3, 1, '.')
+	assert.Equal(t, []string{" .....", ".......", " ....."}, b.Strings())
+}
+
+func ExampleBlox_DrawLine() {
+	b := blox.New().SetColumnsAndRows(21, 5).Trim()
+	b.DrawBox(0, 0, 7, 3, blox.LineASCII).Move(2, 1).PutText("web")
+	b.DrawBox(14, 2, 7, 3, blox.LineASCII).Move(16, 3).PutText("db")
+	b.DrawLine(7, 1, 13, 3)
+	fmt.Println(b.String())
+	// Output:
+	// +-----+
+	// | web |\\
+	// +-----+  \\\  +-----+
+	//             \\| db  |
+	//               +-----+
+}
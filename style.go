@@ -0,0 +1,258 @@
+package blox
+
+import "unicode"
+
+// ColorMode tells how a Color is to be interpreted.
+type ColorMode uint8
+
+const (
+	ColorModeDefault ColorMode = iota // Terminal default color.
+	ColorModeBasic                    // One of the 16 basic ANSI colors (Index 0-15).
+	ColorModeIndexed                  // Index in the xterm 256 color palette.
+	ColorModeRGB                      // 24-bit true color (R, G and B).
+)
+
+// Color is a foreground or background color of a cell. The zero value is the
+// terminal default color.
+type Color struct {
+	Mode  ColorMode
+	Index uint8
+	R     uint8
+	G     uint8
+	B     uint8
+}
+
+// DefaultColor is the terminal default foreground or background color.
+var DefaultColor = Color{}
+
+// The 16 basic ANSI colors.
+var (
+	Black         = Basic(0)
+	Red           = Basic(1)
+	Green         = Basic(2)
+	Yellow        = Basic(3)
+	Blue          = Basic(4)
+	Magenta       = Basic(5)
+	Cyan          = Basic(6)
+	White         = Basic(7)
+	BrightBlack   = Basic(8)
+	BrightRed     = Basic(9)
+	BrightGreen   = Basic(10)
+	BrightYellow  = Basic(11)
+	BrightBlue    = Basic(12)
+	BrightMagenta = Basic(13)
+	BrightCyan    = Basic(14)
+	BrightWhite   = Basic(15)
+)
+
+// Basic returns one of the 16 basic ANSI colors, 0-7 are the normal and 8-15
+// the bright variants. n is wrapped to 0-15.
+func Basic(n uint8) Color {
+	return Color{Mode: ColorModeBasic, Index: n & 0x0f}
+}
+
+// Indexed returns a color from the xterm 256 color palette.
+func Indexed(n uint8) Color {
+	return Color{Mode: ColorModeIndexed, Index: n}
+}
+
+// RGB returns a 24-bit true color.
+func RGB(r, g, b uint8) Color {
+	return Color{Mode: ColorModeRGB, R: r, G: g, B: b}
+}
+
+// IsDefault returns true if c is the terminal default color.
+func (c Color) IsDefault() bool {
+	return c.Mode == ColorModeDefault
+}
+
+// Attribute is a bit mask of text attributes such as bold or underline.
+type Attribute uint8
+
+const (
+	AttrBold Attribute = 1 << iota
+	AttrDim
+	AttrItalic
+	AttrUnderline
+	AttrReverse
+
+	AttrNone Attribute = 0
+)
+
+// Style is the color and attributes of a cell. The zero value is plain text in
+// the terminal default colors.
+type Style struct {
+	Foreground Color
+	Background Color
+	Attributes Attribute
+}
+
+// IsZero returns true if s is plain text in default colors.
+func (s Style) IsZero() bool {
+	return s == Style{}
+}
+
+// Has returns true if all attributes in a are set in s.
+func (s Style) Has(a Attribute) bool {
+	return s.Attributes&a == a
+}
+
+// WithForeground returns a copy of s with foreground color c.
+func (s Style) WithForeground(c Color) Style {
+	s.Foreground = c
+	return s
+}
+
+// WithBackground returns a copy of s with background color c.
+func (s Style) WithBackground(c Color) Style {
+	s.Background = c
+	return s
+}
+
+// WithAttributes returns a copy of s with attributes a added.
+func (s Style) WithAttributes(a ...Attribute) Style {
+	for _, attr := range a {
+		s.Attributes |= attr
+	}
+	return s
+}
+
+// WithoutAttributes returns a copy of s with attributes a removed.
+func (s Style) WithoutAttributes(a ...Attribute) Style {
+	for _, attr := range a {
+		s.Attributes &^= attr
+	}
+	return s
+}
+
+// visibleWhenBlank returns true if a space in this style can be seen, i.e. has
+// a background color, is reversed or underlined. Such cells are not trimmed.
+func (s Style) visibleWhenBlank() bool {
+	return !s.Background.IsDefault() || s.Attributes&(AttrReverse|AttrUnderline) != 0
+}
+
+// Cell is a position on the canvas with its rune, combining marks attached to
+// the rune and style.
+type Cell struct {
+	Rune      rune
+	Combining []rune
+	Style     Style
+}
+
+// SetPen sets the current pen, the style used by PutChar, PutText,
+// DrawHorizontalLine and all other functions writing to the canvas.
+func (b *Blox) SetPen(s Style) *Blox {
+	b.Pen = s
+	return b
+}
+
+// ResetPen sets the current pen to plain text in default colors.
+func (b *Blox) ResetPen() *Blox {
+	return b.SetPen(Style{})
+}
+
+// SetForeground sets the foreground color of the current pen.
+func (b *Blox) SetForeground(c Color) *Blox {
+	b.Pen.Foreground = c
+	return b
+}
+
+// SetBackground sets the background color of the current pen.
+func (b *Blox) SetBackground(c Color) *Blox {
+	b.Pen.Background = c
+	return b
+}
+
+// SetAttributes replaces the attributes of the current pen with a. Call
+// without arguments to clear all attributes.
+func (b *Blox) SetAttributes(a ...Attribute) *Blox {
+	b.Pen.Attributes = AttrNone
+	b.Pen = b.Pen.WithAttributes(a...)
+	return b
+}
+
+// StyleAt returns the style of the cell at column x, row y. Returns the zero
+// Style if x/y is outside the canvas.
+func (b *Blox) StyleAt(x int, y int) Style {
+	return b.styleAtIndex(b.cellIndex(x, y))
+}
+
+// Cells is the styled counterpart of Lines. Returns each row of the canvas
+// and its visible layers as a slice of cells where trailing spaces and final
+// empty lines are trimmed according to TrimRightSpaces and
+// TrimFinalEmptyLines. Unlike Lines, a space is only trimmed if it is not
+// visible in its style (background color, reverse or underline).
+func (b *Blox) Cells() [][]Cell {
+	b = b.composite()
+	rows := b.cellRows()
+	lines := make([][]Cell, 0, rows)
+	for r := 0; r < rows; r++ {
+		lines = append(lines, b.appendCellRow(make([]Cell, 0, b.Columns), r))
+	}
+	return lines
+}
+
+// appendCellRow appends the cells of row y to line, without trailing blank
+// cells if TrimRightSpaces is true. Layers are not composited, see Cells.
+func (b *Blox) appendCellRow(line []Cell, y int) []Cell {
+	s := b.root()
+	start := len(line)
+	for c := 0; c < b.Columns; c++ {
+		i := b.index(c, y)
+		if i < 0 {
+			line = append(line, Cell{Rune: ' '})
+			continue
+		}
+		if s.Canvas[i] == ContinuationCell {
+			continue
+		}
+		line = append(line, Cell{Rune: s.Canvas[i], Combining: s.Combining[i], Style: b.styleAtIndex(i)})
+	}
+	if b.TrimRightSpaces {
+		for len(line) > start && line[len(line)-1].blank() {
+			line = line[:len(line)-1]
+		}
+	}
+	return line
+}
+
+// cellRows is the Cells counterpart of outputRows, returns the number of rows
+// in styled output.
+func (b *Blox) cellRows() int {
+	rows := b.Rows
+	if !b.TrimFinalEmptyLines {
+		return rows
+	}
+	var line []Cell
+	for ; rows > 0; rows-- {
+		line = b.appendCellRow(line[:0], rows-1)
+		if !cellsBlank(line) {
+			break
+		}
+	}
+	return rows
+}
+
+// blank returns true if the cell is white space that can not be seen.
+func (c Cell) blank() bool {
+	return unicode.IsSpace(c.Rune) && len(c.Combining) == 0 && !c.Style.visibleWhenBlank()
+}
+
+func cellsBlank(cells []Cell) bool {
+	for _, c := range cells {
+		if !c.blank() {
+			return false
+		}
+	}
+	return true
+}
+
+// styleAtIndex returns the style at canvas index i or the zero Style if there
+// is no style information for i.
+func (b *Blox) styleAtIndex(i int) Style {
+	s := b.root()
+	if i < 0 || i >= len(s.Styles) {
+		return Style{}
+	}
+	return s.Styles[i]
+}
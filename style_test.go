@@ -0,0 +1,52 @@
+package blox_test
+
+import (
+	"testing"
+
+	"github.com/sa6mwa/blox"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPen(t *testing.T) {
+	b := blox.New().SetColumnsAndRows(10, 2).Trim()
+	b.SetForeground(blox.Red).SetAttributes(blox.AttrBold).PutText("HI").
+		ResetPen().PutText("THERE")
+
+	bold := blox.Style{Foreground: blox.Red, Attributes: blox.AttrBold}
+	assert.Equal(t, bold, b.StyleAt(0, 0))
+	assert.Equal(t, bold, b.StyleAt(1, 0))
+	assert.True(t, b.StyleAt(0, 1).IsZero())
+	assert.True(t, b.StyleAt(100, 100).IsZero())
+	assert.Equal(t, "HI"+blox.LineBreak+"THERE"+blox.LineBreak, b.String())
+}
+
+func TestDrawWithPen(t *testing.T) {
+	b := blox.New().SetColumnsAndRows(5, 1).SetPen(blox.Style{Foreground: blox.RGB(1, 2, 3)})
+	b.DrawHorizontalLine(0, 4)
+	for x := 0; x < 5; x++ {
+		assert.Equal(t, blox.RGB(1, 2, 3), b.StyleAt(x, 0).Foreground)
+	}
+}
+
+func TestCells(t *testing.T) {
+	b := blox.New().SetColumnsAndRows(6, 3).Trim()
+	b.PutText("A").SetBackground(blox.Indexed(22)).PutText("B  ")
+
+	cells := b.Cells()
+	assert.Len(t, cells, 2)
+	assert.Equal(t, []blox.Cell{{Rune: 'A'}}, cells[0])
+	// Spaces with a background color are not trimmed.
+	assert.Len(t, cells[1], 3)
+	assert.Equal(t, 'B', cells[1][0].Rune)
+	assert.Equal(t, blox.Indexed(22), cells[1][2].Style.Background)
+}
+
+func TestStyle(t *testing.T) {
+	s := blox.Style{}.WithForeground(blox.Green).WithAttributes(blox.AttrBold, blox.AttrUnderline)
+	assert.True(t, s.Has(blox.AttrBold|blox.AttrUnderline))
+	s = s.WithoutAttributes(blox.AttrBold)
+	assert.False(t, s.Has(blox.AttrBold))
+	assert.True(t, s.Has(blox.AttrUnderline))
+	assert.Equal(t, blox.Basic(15), blox.Basic(31))
+	assert.True(t, blox.DefaultColor.IsDefault())
+}
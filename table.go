@@ -0,0 +1,463 @@
+package blox
+
+import (
+	"bufio"
+	"strings"
+)
+
+// TableColumn holds the settings of a column in a Table. MinWidth and
+// MaxWidth are in terminal columns excluding padding, a MaxWidth of 0 means
+// no maximum.
+type TableColumn struct {
+	Align    HorizontalAlignment
+	MinWidth int
+	MaxWidth int
+}
+
+// TableCell is a cell of a Table. Text can have line breaks. Span is the
+// number of columns the cell covers, 0 or 1 is a normal single column cell.
+// Spanning cells use the alignment of the first column they cover.
+type TableCell struct {
+	Text string
+	Span int
+}
+
+// Span returns a TableCell with text covering columns columns.
+func Span(text string, columns int) TableCell {
+	return TableCell{Text: text, Span: columns}
+}
+
+// Table is a table rendered into a Blox by PutTable. Create one with NewTable
+// and add rows with AddRow or AddCells:
+//
+//	t := blox.NewTable("Name", "Qty").SetColumn(1, blox.AlignRight, 0, 0)
+//	t.AddRow("Apples", "12").AddRow("Pears", "3")
+//	blox.New().SetColumnsAndRows(40, 6).Trim().PutTable(t).PrintCanvas()
+//
+//	┌────────┬─────┐
+//	│ Name   │ Qty │
+//	├────────┼─────┤
+//	│ Apples │  12 │
+//	│ Pears  │   3 │
+//	└────────┴─────┘
+//
+// Columns are as wide as their widest cell within the minimum and maximum
+// width of the column. If the table does not fit the width available from
+// the cursor to the right edge of the canvas, the widest columns are narrowed
+// until it does. Cell text that does not fit is truncated with dots (see
+// CutLineShortWidth) or word-wrapped onto more rows if Wrap is true.
+type Table struct {
+	Header        []TableCell
+	Rows          [][]TableCell
+	Columns       []TableColumn
+	Border        LineStyle
+	Borderless    bool // No border, columns are separated by two spaces.
+	RowSeparators bool // Draw a line between each row.
+	Wrap          bool // Wrap cell text instead of truncating it.
+}
+
+// NewTable returns a new Table with header as column headings, a table with
+// no header row is created if header is empty. The default border style is
+// LineLight.
+func NewTable(header ...string) *Table {
+	t := &Table{
+		Border: LineLight,
+	}
+	for _, h := range header {
+		t.Header = append(t.Header, TableCell{Text: h})
+	}
+	return t
+}
+
+// AddRow adds a row of single column cells to the table.
+func (t *Table) AddRow(cells ...string) *Table {
+	row := make([]TableCell, 0, len(cells))
+	for _, c := range cells {
+		row = append(row, TableCell{Text: c})
+	}
+	t.Rows = append(t.Rows, row)
+	return t
+}
+
+// AddCells adds a row of cells to the table, use it for cells spanning
+// several columns (see Span).
+func (t *Table) AddCells(cells ...TableCell) *Table {
+	t.Rows = append(t.Rows, cells)
+	return t
+}
+
+// SetColumn sets the alignment and minimum and maximum width (0 is no
+// maximum) of column (0 is the first).
+func (t *Table) SetColumn(column int, align HorizontalAlignment, minWidth int, maxWidth int) *Table {
+	if column < 0 {
+		return t
+	}
+	for len(t.Columns) <= column {
+		t.Columns = append(t.Columns, TableColumn{})
+	}
+	t.Columns[column] = TableColumn{Align: align, MinWidth: minWidth, MaxWidth: maxWidth}
+	return t
+}
+
+// SetBorder sets the line style of the border and separators.
+func (t *Table) SetBorder(style LineStyle) *Table {
+	t.Border = style
+	return t
+}
+
+// SetBorderless turns the border off (true) or on (false).
+func (t *Table) SetBorderless(borderless bool) *Table {
+	t.Borderless = borderless
+	return t
+}
+
+// SetRowSeparators turns lines between rows on or off.
+func (t *Table) SetRowSeparators(separators bool) *Table {
+	t.RowSeparators = separators
+	return t
+}
+
+// SetWrap selects word-wrapping (true) or truncation (false) of cell text
+// that does not fit the column.
+func (t *Table) SetWrap(wrap bool) *Table {
+	t.Wrap = wrap
+	return t
+}
+
+// placedCell is a cell positioned in the column grid of a table.
+type placedCell struct {
+	column int
+	span   int
+	text   string
+}
+
+// tableRow is a row of a table laid out for rendering.
+type tableRow struct {
+	cells  []placedCell
+	lines  [][]string // Text lines of each cell.
+	height int
+}
+
+// boundaries returns which of the column boundaries (0 is the left edge,
+// len(widths) the right edge) have a vertical line in row r.
+func (r *tableRow) boundaries(columns int) []bool {
+	bounds := make([]bool, columns+1)
+	bounds[0] = true
+	bounds[columns] = true
+	for _, c := range r.cells {
+		bounds[c.column+c.span] = true
+	}
+	return bounds
+}
+
+// placeCells positions cells in the column grid, clipping spans at the last
+// of columns (if columns is greater than 0).
+func placeCells(cells []TableCell, columns int) []placedCell {
+	placed := make([]placedCell, 0, len(cells))
+	column := 0
+	for _, c := range cells {
+		span := c.Span
+		if span < 1 {
+			span = 1
+		}
+		if columns > 0 {
+			if column >= columns {
+				break
+			}
+			if column+span > columns {
+				span = columns - column
+			}
+		}
+		placed = append(placed, placedCell{column: column, span: span, text: c.Text})
+		column += span
+	}
+	return placed
+}
+
+// columnCount returns the number of columns of the table.
+func (t *Table) columnCount() int {
+	n := len(t.Columns)
+	count := func(cells []TableCell) {
+		placed := placeCells(cells, 0)
+		if len(placed) > 0 {
+			if last := placed[len(placed)-1]; last.column+last.span > n {
+				n = last.column + last.span
+			}
+		}
+	}
+	count(t.Header)
+	for _, row := range t.Rows {
+		count(row)
+	}
+	return n
+}
+
+// column returns the settings of column i.
+func (t *Table) column(i int) TableColumn {
+	if i < len(t.Columns) {
+		return t.Columns[i]
+	}
+	return TableColumn{}
+}
+
+// gutter returns the number of terminal columns between the text of two
+// adjacent columns.
+func (t *Table) gutter() int {
+	if t.Borderless {
+		return 2
+	}
+	return 3 // Padding, vertical line, padding.
+}
+
+// spanWidth returns the width available to text in a cell covering span
+// columns starting at column.
+func (t *Table) spanWidth(widths []int, column int, span int) int {
+	w := (span - 1) * t.gutter()
+	for i := column; i < column+span; i++ {
+		w += widths[i]
+	}
+	return w
+}
+
+// columnWidths returns the width of each column fitted to available terminal
+// columns.
+func (t *Table) columnWidths(rows [][]placedCell, columns int, available int) []int {
+	widths := make([]int, columns)
+	minimum := make([]int, columns)
+	for i := range widths {
+		minimum[i] = maxInt(1, t.column(i).MinWidth)
+		widths[i] = minimum[i]
+	}
+	for _, row := range rows {
+		for _, c := range row {
+			if c.span == 1 {
+				widths[c.column] = maxInt(widths[c.column], MaximumLineWidth(c.text))
+			}
+		}
+	}
+	for i := range widths {
+		if maxWidth := t.column(i).MaxWidth; maxWidth > 0 && widths[i] > maxWidth {
+			widths[i] = maxInt(maxWidth, minimum[i])
+		}
+	}
+	// Widen the columns covered by a spanning cell that does not fit, one
+	// terminal column at a time round-robin over columns below their maximum.
+	for _, row := range rows {
+		for _, c := range row {
+			if c.span == 1 {
+				continue
+			}
+			need := MaximumLineWidth(c.text) - t.spanWidth(widths, c.column, c.span)
+			for need > 0 {
+				widened := false
+				for i := c.column; i < c.column+c.span && need > 0; i++ {
+					if maxWidth := t.column(i).MaxWidth; maxWidth > 0 && widths[i] >= maxWidth {
+						continue
+					}
+					widths[i]++
+					need--
+					widened = true
+				}
+				if !widened {
+					break
+				}
+			}
+		}
+	}
+	total := func() int {
+		w := t.spanWidth(widths, 0, columns)
+		if !t.Borderless {
+			w += 4 // Left and right line and padding.
+		}
+		return w
+	}
+	for total() > available {
+		widest := -1
+		for i := range widths {
+			if widths[i] > minimum[i] && (widest < 0 || widths[i] > widths[widest]) {
+				widest = i
+			}
+		}
+		if widest < 0 {
+			break
+		}
+		widths[widest]--
+	}
+	return widths
+}
+
+// cellLines returns text as lines fitted to width terminal columns and, for
+// each line, whether it was wrapped (is not the last line of a paragraph).
+func (t *Table) cellLines(text string, width int) ([]string, []bool) {
+	var lines []string
+	var wrapped []bool
+	s := bufio.NewScanner(strings.NewReader(text))
+	for s.Scan() {
+		if !t.Wrap {
+			lines = append(lines, CutLineShortWidth(s.Text(), width, true))
+			wrapped = append(wrapped, false)
+			continue
+		}
+		runes := []rune(s.Text())
+		for {
+			line, rest, w := wrapRunes(runes, width)
+			lines = append(lines, string(line))
+			for len(rest) > 0 && isWrapSpace(rest[0]) {
+				rest = rest[1:]
+			}
+			wrapped = append(wrapped, w && len(rest) > 0)
+			if len(rest) == 0 {
+				break
+			}
+			runes = rest
+		}
+	}
+	if len(lines) == 0 {
+		return []string{""}, []bool{false}
+	}
+	return lines, wrapped
+}
+
+// PutTable renders table t with the upper left hand corner at the cursor
+// position, fitted to the width from the cursor to the right edge of the
+// canvas. Rows that do not fit below the cursor are clipped. Lines of the
+// border merge with lines already on the canvas (see DrawBox). LineSpacing
+// is not used within a table. The cursor is left at the original column on
+// the row after the table.
+func (b *Blox) PutTable(t *Table) *Blox {
+	columns := t.columnCount()
+	if columns == 0 || b.pastCanvas() {
+		return b
+	}
+	x0, y := b.Cursor.X, b.Cursor.Y
+	placed := make([][]placedCell, 0, len(t.Rows)+1)
+	if len(t.Header) > 0 {
+		placed = append(placed, placeCells(t.Header, columns))
+	}
+	for _, row := range t.Rows {
+		placed = append(placed, placeCells(row, columns))
+	}
+	widths := t.columnWidths(placed, columns, b.Columns-x0-b.originX)
+	// textX is the column where the text of each table column starts, bounds
+	// is where the vertical line to the left of each table column is (and the
+	// right edge of the table last).
+	textX := make([]int, columns)
+	bounds := make([]int, columns+1)
+	x := x0
+	if !t.Borderless {
+		x += 2
+	}
+	for i := range widths {
+		textX[i] = x
+		bounds[i] = x - 2
+		x += widths[i] + t.gutter()
+	}
+	bounds[columns] = x - 2
+	rows := make([]tableRow, 0, len(placed))
+	for _, cells := range placed {
+		row := tableRow{cells: cells, height: 1}
+		for _, c := range cells {
+			lines, wrapped := t.cellLines(c.text, t.spanWidth(widths, c.column, c.span))
+			if t.column(c.column).Align == AlignJustify {
+				for i := range lines {
+					if wrapped[i] {
+						lines[i] = justify(lines[i], t.spanWidth(widths, c.column, c.span))
+					}
+				}
+			}
+			row.lines = append(row.lines, lines)
+			row.height = maxInt(row.height, len(lines))
+		}
+		rows = append(rows, row)
+	}
+	if len(rows) == 0 {
+		return b
+	}
+	style := t.Border
+	weight := style.weight()
+	// hline draws a horizontal line at row y connecting to the vertical lines
+	// of the rows above and below (nil for none).
+	hline := func(y int, above *tableRow, below *tableRow) {
+		if t.Borderless {
+			if above == nil {
+				return
+			}
+			for _, c := range above.cells {
+				b.DrawHorizontalRule(textX[c.column], y, t.spanWidth(widths, c.column, c.span), style)
+			}
+			return
+		}
+		var up, down []bool
+		if above != nil {
+			up = above.boundaries(columns)
+		}
+		if below != nil {
+			down = below.boundaries(columns)
+		}
+		left, right := bounds[0], bounds[columns]
+		boundary := 0
+		for x := left; x <= right; x++ {
+			var a arms
+			if x > left {
+				a[armLeft] = weight
+			}
+			if x < right {
+				a[armRight] = weight
+			}
+			if boundary <= columns && x == bounds[boundary] {
+				if up != nil && up[boundary] {
+					a[armUp] = weight
+				}
+				if down != nil && down[boundary] {
+					a[armDown] = weight
+				}
+				boundary++
+			}
+			b.drawArms(x, y, a, style)
+		}
+	}
+	if !t.Borderless {
+		hline(y, nil, &rows[0])
+		y++
+	}
+	header := len(t.Header) > 0
+	for r := range rows {
+		row := &rows[r]
+		var bounded []bool
+		if !t.Borderless {
+			bounded = row.boundaries(columns)
+		}
+		for line := 0; line < row.height; line++ {
+			for i, c := range row.cells {
+				if line >= len(row.lines[i]) {
+					continue
+				}
+				text := row.lines[i][line]
+				w := t.spanWidth(widths, c.column, c.span)
+				x := alignedX(t.column(c.column).Align, StringWidth(text), w)
+				b.Region(textX[c.column], y, w, 1).Move(maxInt(x, 0), 0).PutLine([]rune(text))
+			}
+			for i, v := range bounded {
+				if v {
+					b.drawArms(bounds[i], y, arms{armUp: weight, armDown: weight}, style)
+				}
+			}
+			y++
+		}
+		var next *tableRow
+		if r+1 < len(rows) {
+			next = &rows[r+1]
+		}
+		switch {
+		case next == nil:
+			if !t.Borderless {
+				hline(y, row, nil)
+				y++
+			}
+		case r == 0 && header, t.RowSeparators:
+			hline(y, row, next)
+			y++
+		}
+	}
+	return b.Move(x0, y)
+}
@@ -0,0 +1,85 @@
+package blox_test
+
+import (
+	"testing"
+
+	"github.com/sa6mwa/blox"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPutTable(t *testing.T) {
+	table := blox.NewTable("Name", "Qty").SetColumn(1, blox.AlignRight, 0, 0)
+	table.AddRow("Apples", "12").AddRow("Pears", "3")
+	b := blox.New().SetColumnsAndRows(30, 8).Trim().Move(2, 1).PutTable(table)
+	expect := []string{
+		"",
+		"  ┌────────┬─────┐",
+		"  │ Name   │ Qty │",
+		"  ├────────┼─────┤",
+		"  │ Apples │  12 │",
+		"  │ Pears  │   3 │",
+		"  └────────┴─────┘",
+	}
+	assert.Equal(t, expect, b.Strings())
+	assert.Equal(t, 2, b.Cursor.X)
+	assert.Equal(t, 7, b.Cursor.Y)
+}
+
+func TestPutTableSpanAndSeparators(t *testing.T) {
+	table := blox.NewTable("A", "B", "C").SetBorder(blox.LineDouble).SetRowSeparators(true)
+	table.AddCells(blox.Span("wide", 2), blox.TableCell{Text: "c"}).
+		AddCells(blox.TableCell{Text: "a"}, blox.Span("bc", 2))
+	b := blox.New().SetColumnsAndRows(20, 8).Trim().PutTable(table)
+	expect := []string{
+		"╔═══╦═══╦═══╗",
+		"║ A ║ B ║ C ║",
+		"╠═══╩═══╬═══╣",
+		"║ wide  ║ c ║",
+		"╠═══╦═══╩═══╣",
+		"║ a ║ bc    ║",
+		"╚═══╩═══════╝",
+	}
+	assert.Equal(t, expect, b.Strings())
+}
+
+func TestPutTableFitAndWrap(t *testing.T) {
+	table := blox.NewTable("Key", "Description").SetBorder(blox.LineASCII)
+	table.AddRow("a", "The quick brown fox jumps")
+	b := blox.New().SetColumnsAndRows(20, 8).Trim().PutTable(table)
+	expect := []string{
+		"+-----+------------+",
+		"| Key | Descripti… |",
+		"+-----+------------+",
+		"| a   | The quick… |",
+		"+-----+------------+",
+	}
+	assert.Equal(t, expect, b.Strings())
+
+	b = blox.New().SetColumnsAndRows(20, 8).Trim().PutTable(table.SetWrap(true))
+	expect = []string{
+		"+-----+------------+",
+		"| Key | Descriptio |",
+		"|     | n          |",
+		"+-----+------------+",
+		"| a   | The quick  |",
+		"|     | brown fox  |",
+		"|     | jumps      |",
+		"+-----+------------+",
+	}
+	assert.Equal(t, expect, b.Strings())
+}
+
+func TestPutTableBorderless(t *testing.T) {
+	table := blox.NewTable("Name", "Qty").SetBorderless(true).
+		SetColumn(0, blox.AlignLeft, 8, 0).SetColumn(1, blox.AlignRight, 0, 0)
+	table.AddRow("Apples", "12").AddRow("Pineapples", "3")
+	table.SetColumn(0, blox.AlignLeft, 8, 8)
+	b := blox.New().SetColumnsAndRows(20, 5).Trim().PutTable(table)
+	expect := []string{
+		"Name      Qty",
+		"────────  ───",
+		"Apples     12",
+		"Pineapp…    3",
+	}
+	assert.Equal(t, expect, b.Strings())
+}
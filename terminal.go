@@ -0,0 +1,368 @@
+package blox
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Terminal is an io.Writer that writes to a Blox (typically a Region) like a
+// small virtual terminal, for example to capture the output of a command:
+//
+//	cmd.Stdout = b.Region(0, 2, 80, 20).Terminal()
+//
+// Printable characters are written with the pen of the Blox and wrap onto the
+// next row at the right edge. When output goes past the bottom row, the
+// content scrolls up into the scrollback buffer if enabled (see
+// SetScrollback). A Region gets the scrollback limit of its parent but keeps
+// a scrollback of its own. Control characters are interpreted:
+//
+//	\n      line feed, also returns to the first column (like onlcr)
+//	\r      carriage return
+//	\t      move to the next tab stop (every TabWidth columns)
+//	\b      backspace, move one column left
+//	\v \f   same as line feed
+//
+// If ANSI is true, a subset of the ANSI escape sequences is interpreted:
+// cursor movement (CSI A B C D E F G H f), erase in display and line (CSI J,
+// CSI K) and select graphic rendition (CSI m, sets the pen). Other escape
+// sequences are removed from the output. Combining marks are attached to the
+// character before them, but a grapheme cluster made of several characters
+// of their own (emoji joined by ZWJ, flags) is written one character per
+// cell.
+type Terminal struct {
+	Blox     *Blox
+	TabWidth int  // Columns between tab stops, 8 if 0.
+	ANSI     bool // Interpret ANSI escape sequences.
+	x        int
+	y        int
+	wrap     bool   // Next character goes on the next row (right edge reached).
+	last     int    // Canvas index+1 of the last character written.
+	state    uint8  // Escape sequence parser state.
+	sequence []byte // Parameters of the escape sequence being parsed.
+	pending  []byte // Incomplete UTF-8 encoded rune from the last Write.
+}
+
+// Escape sequence parser states of Terminal.
+const (
+	termGround uint8 = iota
+	termEscape
+	termEscapeIntermediate
+	termCSI
+	termOSC
+	termOSCEscape
+)
+
+// Terminal returns an io.Writer interpreting control characters and ANSI
+// escape sequences that writes to b starting at the cursor position, see
+// Terminal.
+func (b *Blox) Terminal() *Terminal {
+	return &Terminal{
+		Blox:     b,
+		TabWidth: 8,
+		ANSI:     true,
+		x:        b.Cursor.X,
+		y:        b.Cursor.Y,
+	}
+}
+
+// Write implements io.Writer, interprets p as UTF-8 encoded terminal output
+// and writes it to the Blox. Runes and escape sequences split across calls to
+// Write are handled. Always returns len(p) and a nil error.
+func (t *Terminal) Write(p []byte) (int, error) {
+	n := len(p)
+	if len(t.pending) > 0 {
+		p = append(t.pending, p...)
+		t.pending = nil
+	}
+	for len(p) > 0 {
+		if !utf8.FullRune(p) {
+			t.pending = append(t.pending, p...)
+			break
+		}
+		r, size := utf8.DecodeRune(p)
+		p = p[size:]
+		t.interpret(r)
+	}
+	if t.Blox.Columns > 0 && t.Blox.Rows > 0 {
+		t.Blox.Move(minInt(t.x, t.Blox.Columns-1), t.y)
+		t.Blox.lastPut = t.last
+	}
+	return n, nil
+}
+
+// interpret handles r according to the parser state.
+func (t *Terminal) interpret(r rune) {
+	switch t.state {
+	case termEscape:
+		switch r {
+		case '[':
+			t.state = termCSI
+			t.sequence = t.sequence[:0]
+		case ']':
+			t.state = termOSC
+		default:
+			if r >= 0x20 && r < 0x30 {
+				// Intermediate byte, for example ESC ( B.
+				t.state = termEscapeIntermediate
+				return
+			}
+			t.state = termGround
+		}
+		return
+	case termEscapeIntermediate:
+		if r < 0x20 || r >= 0x30 {
+			t.state = termGround
+		}
+		return
+	case termCSI:
+		switch {
+		case r >= 0x40 && r <= 0x7e:
+			t.state = termGround
+			t.csi(r)
+		case r >= 0x20 && r < 0x40:
+			t.sequence = append(t.sequence, byte(r))
+		default:
+			t.state = termGround
+		}
+		return
+	case termOSC:
+		switch r {
+		case 0x07:
+			t.state = termGround
+		case 0x1b:
+			t.state = termOSCEscape
+		}
+		return
+	case termOSCEscape:
+		t.state = termGround
+		return
+	}
+	switch r {
+	case '\n', '\v', '\f':
+		t.lineFeed()
+	case '\r':
+		t.moveTo(0, t.y)
+	case '\t':
+		tab := t.TabWidth
+		if tab <= 0 {
+			tab = 8
+		}
+		t.moveTo(minInt((t.x/tab+1)*tab, t.Blox.Columns-1), t.y)
+	case '\b':
+		t.moveTo(t.x-1, t.y)
+	case 0x1b:
+		if t.ANSI {
+			t.state = termEscape
+		}
+	default:
+		t.put(r)
+	}
+}
+
+// put writes a printable rune at the terminal cursor.
+func (t *Terminal) put(r rune) {
+	b := t.Blox
+	if b.Columns == 0 || b.Rows == 0 {
+		return
+	}
+	w := RuneWidth(r)
+	if w == 0 {
+		if IsCombining(r) && t.last > 0 {
+			b.attachCombining(t.last-1, r)
+		}
+		return
+	}
+	if t.wrap || t.x+w > b.Columns {
+		t.lineFeed()
+	}
+	b.Move(t.x, t.y).putCluster([]rune{r})
+	t.last = b.lastPut
+	t.x += w
+	if t.x >= b.Columns {
+		t.x = b.Columns - 1
+		t.wrap = true
+	}
+}
+
+// moveTo moves the terminal cursor to column x, row y clamped to the canvas.
+func (t *Terminal) moveTo(x int, y int) {
+	t.x = maxInt(0, minInt(x, t.Blox.Columns-1))
+	t.y = maxInt(0, minInt(y, t.Blox.Rows-1))
+	t.wrap = false
+	t.last = 0
+}
+
+// lineFeed moves the terminal cursor to the first column of the next row,
+// scrolling the content up if the cursor is on the bottom row.
+func (t *Terminal) lineFeed() {
+	if t.y+1 >= t.Blox.Rows {
+		t.Blox.ScrollUp(1)
+		t.moveTo(0, t.y)
+		return
+	}
+	t.moveTo(0, t.y+1)
+}
+
+// csi executes the control sequence ending in final.
+func (t *Terminal) csi(final rune) {
+	if len(t.sequence) > 0 && (t.sequence[0] < '0' || t.sequence[0] > ';') {
+		return // Private (DEC) sequences are not supported.
+	}
+	params := parseParams(string(t.sequence))
+	n := 1
+	if len(params) > 0 && params[0] > 0 {
+		n = params[0]
+	}
+	switch final {
+	case 'A':
+		t.moveTo(t.x, t.y-n)
+	case 'B':
+		t.moveTo(t.x, t.y+n)
+	case 'C':
+		t.moveTo(t.x+n, t.y)
+	case 'D':
+		t.moveTo(t.x-n, t.y)
+	case 'E':
+		t.moveTo(0, t.y+n)
+	case 'F':
+		t.moveTo(0, t.y-n)
+	case 'G':
+		t.moveTo(n-1, t.y)
+	case 'H', 'f':
+		column := 1
+		if len(params) > 1 && params[1] > 0 {
+			column = params[1]
+		}
+		t.moveTo(column-1, n-1)
+	case 'J':
+		t.eraseDisplay(param(params, 0))
+	case 'K':
+		t.eraseLine(t.y, param(params, 0))
+	case 'm':
+		t.Blox.Pen = sgr(t.Blox.Pen, params)
+	}
+}
+
+// param returns parameter i or 0 if it was omitted.
+func param(params []int, i int) int {
+	if i < len(params) {
+		return params[i]
+	}
+	return 0
+}
+
+// parseParams parses the semicolon (or colon) separated parameters of a
+// control sequence, omitted parameters are 0.
+func parseParams(s string) []int {
+	if s == "" {
+		return nil
+	}
+	fields := strings.Split(strings.ReplaceAll(s, ":", ";"), ";")
+	params := make([]int, 0, len(fields))
+	for _, f := range fields {
+		n, _ := strconv.Atoi(f)
+		params = append(params, n)
+	}
+	return params
+}
+
+// eraseLine blanks row y from the cursor to the end (mode 0), from the start
+// to the cursor (mode 1) or the entire row (mode 2).
+func (t *Terminal) eraseLine(y int, mode int) {
+	from, to := t.x, t.Blox.Columns-1
+	switch mode {
+	case 1:
+		from, to = 0, t.x
+	case 2:
+		from = 0
+	}
+	for x := from; x <= to; x++ {
+		t.Blox.setCell(x, y, ' ')
+	}
+	t.last = 0
+}
+
+// eraseDisplay blanks from the cursor to the end (mode 0), from the start to
+// the cursor (mode 1) or everything (mode 2 and 3).
+func (t *Terminal) eraseDisplay(mode int) {
+	switch mode {
+	case 0:
+		t.eraseLine(t.y, 0)
+		for y := t.y + 1; y < t.Blox.Rows; y++ {
+			t.eraseLine(y, 2)
+		}
+	case 1:
+		for y := 0; y < t.y; y++ {
+			t.eraseLine(y, 2)
+		}
+		t.eraseLine(t.y, 1)
+	default:
+		for y := 0; y < t.Blox.Rows; y++ {
+			t.eraseLine(y, 2)
+		}
+	}
+}
+
+// sgr returns style changed by the parameters of a select graphic rendition
+// sequence.
+func sgr(style Style, params []int) Style {
+	if len(params) == 0 {
+		return Style{}
+	}
+	for i := 0; i < len(params); i++ {
+		p := params[i]
+		switch {
+		case p == 0:
+			style = Style{}
+		case p == 1:
+			style.Attributes |= AttrBold
+		case p == 2:
+			style.Attributes |= AttrDim
+		case p == 3:
+			style.Attributes |= AttrItalic
+		case p == 4:
+			style.Attributes |= AttrUnderline
+		case p == 7:
+			style.Attributes |= AttrReverse
+		case p == 22:
+			style.Attributes &^= AttrBold | AttrDim
+		case p == 23:
+			style.Attributes &^= AttrItalic
+		case p == 24:
+			style.Attributes &^= AttrUnderline
+		case p == 27:
+			style.Attributes &^= AttrReverse
+		case p >= 30 && p <= 37:
+			style.Foreground = Basic(uint8(p - 30))
+		case p >= 90 && p <= 97:
+			style.Foreground = Basic(uint8(p - 90 + 8))
+		case p == 39:
+			style.Foreground = DefaultColor
+		case p >= 40 && p <= 47:
+			style.Background = Basic(uint8(p - 40))
+		case p >= 100 && p <= 107:
+			style.Background = Basic(uint8(p - 100 + 8))
+		case p == 49:
+			style.Background = DefaultColor
+		case p == 38, p == 48:
+			var c Color
+			switch param(params, i+1) {
+			case 5:
+				c = Indexed(uint8(param(params, i+2)))
+				i += 2
+			case 2:
+				c = RGB(uint8(param(params, i+2)), uint8(param(params, i+3)), uint8(param(params, i+4)))
+				i += 4
+			default:
+				return style
+			}
+			if p == 38 {
+				style.Foreground = c
+			} else {
+				style.Background = c
+			}
+		}
+	}
+	return style
+}
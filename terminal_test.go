@@ -0,0 +1,94 @@
+package blox_test
+
+import (
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/sa6mwa/blox"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTerminal(t *testing.T) {
+	b := blox.New().SetColumnsAndRows(10, 3).Trim()
+	var w io.Writer = b.Terminal()
+	fmt.Fprint(w, "one\ntwo\rT\tx\n")
+	assert.Equal(t, []string{"one", "Two     x"}, b.Strings())
+	fmt.Fprint(w, "abc\b\bX\nfour\nfive")
+	assert.Equal(t, []string{"aXc", "four", "five"}, b.Strings(), "scrolled up one row")
+	assert.Equal(t, 4, b.Cursor.X)
+	assert.Equal(t, 2, b.Cursor.Y)
+	fmt.Fprint(w, "\r0123456789ABC")
+	assert.Equal(t, []string{"four", "0123456789", "ABC"}, b.Strings(), "wrapped at the right edge")
+}
+
+func TestTerminalSplitWrites(t *testing.T) {
+	b := blox.New().SetColumnsAndRows(10, 2).Trim()
+	term := b.Terminal()
+	data := []byte("hé\x1b[1;31mllo\x1b[0m é")
+	for i := range data {
+		term.Write(data[i : i+1])
+	}
+	assert.Equal(t, []string{"héllo é"}, b.Strings())
+	assert.Equal(t, blox.Style{Foreground: blox.Red, Attributes: blox.AttrBold}, b.StyleAt(2, 0))
+	assert.Equal(t, blox.Style{}, b.StyleAt(5, 0))
+	assert.Equal(t, blox.Style{}, b.Pen)
+}
+
+func TestTerminalANSI(t *testing.T) {
+	b := blox.New().SetColumnsAndRows(10, 4).Trim()
+	term := b.Terminal()
+	fmt.Fprint(term, "1111111111222222222233333333334444444444")
+	fmt.Fprint(term, "\x1b[2;3H\x1b[K\x1b[Bx\x1b[1Ky\x1b[4;1H\x1b[2K\x1b]0;title\x07\x1b[?25lend")
+	expect := []string{
+		"1111111111",
+		"22",
+		"   y333333",
+		"end",
+	}
+	assert.Equal(t, expect, b.Strings())
+	fmt.Fprint(term, "\x1b[A\x1b[3D\x1b[1J\x1b[38;5;21;48;2;1;2;3mz")
+	assert.Equal(t, []string{"", "", "z  y333333", "end"}, b.Strings())
+	assert.Equal(t, blox.Style{Foreground: blox.Indexed(21), Background: blox.RGB(1, 2, 3)}, b.StyleAt(0, 2))
+	fmt.Fprint(term, "\x1b[1;;4mu\x1b[;1mv")
+	assert.Equal(t, blox.Style{Attributes: blox.AttrUnderline}, b.StyleAt(1, 2), "an empty parameter is a reset")
+	assert.Equal(t, blox.Style{Attributes: blox.AttrBold}, b.StyleAt(2, 2))
+
+	fmt.Fprint(term, "\x1b(B\x1b7w\x1b8\x1b#8")
+	assert.Equal(t, "zuvw", b.Strings()[2][:4], "escape sequences without CSI or OSC are removed")
+
+	term.ANSI = false
+	fmt.Fprint(term, "\x1b[2J")
+	assert.Equal(t, "zuvw[2J", b.Strings()[2][:7])
+}
+
+func TestTerminalRegion(t *testing.T) {
+	b := blox.New().SetColumnsAndRows(12, 4).Trim().DrawBox(0, 0, 12, 4, blox.LineASCII)
+	fmt.Fprint(b.Region(1, 1, 10, 2).Terminal(), "line 1\nline 2\nline 3 is long\n")
+	expect := []string{
+		"+----------+",
+		"|long      |",
+		"|          |",
+		"+----------+",
+	}
+	assert.Equal(t, expect, b.Strings())
+
+	b = blox.New().SetColumnsAndRows(4, 3).SetScrollback(10)
+	r := b.Region(0, 1, 4, 2)
+	fmt.Fprint(r.Terminal(), "a\nb\nc\nd\n")
+	if assert.Len(t, r.Scrollback, 3, "the region inherits the scrollback limit") {
+		assert.Equal(t, 'c', r.Scrollback[2][0].Rune)
+	}
+	assert.Empty(t, b.Scrollback)
+}
+
+func ExampleBlox_Terminal() {
+	b := blox.New().SetColumnsAndRows(20, 4).Trim().DrawBox(0, 0, 20, 4, blox.LineLight)
+	fmt.Fprint(b.Region(1, 1, 18, 2).Terminal(), "$ make\nok\tbuild\n$ ")
+	fmt.Print(b.String())
+	// Output:
+	// ┌──────────────────┐
+	// │ok      build     │
+	// │$                 │
+	// └──────────────────┘
+}
@@ -0,0 +1,202 @@
+package blox
+
+import (
+	"bufio"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// ContinuationCell marks the second cell occupied by a wide (East Asian wide
+// or full-width) character. Output functions skip it as the character before
+// already covers both terminal columns.
+const ContinuationCell rune = -1
+
+// runeRange is an inclusive range of runes.
+type runeRange struct {
+	first rune
+	last  rune
+}
+
+// wideRanges are the East Asian Wide (W) and Fullwidth (F) ranges including
+// emoji with default emoji presentation, sorted for binary search.
+var wideRanges = []runeRange{
+	{0x1100, 0x115F}, {0x231A, 0x231B}, {0x2329, 0x232A}, {0x23E9, 0x23EC},
+	{0x23F0, 0x23F0}, {0x23F3, 0x23F3}, {0x25FD, 0x25FE}, {0x2614, 0x2615},
+	{0x2648, 0x2653}, {0x267F, 0x267F}, {0x2693, 0x2693}, {0x26A1, 0x26A1},
+	{0x26AA, 0x26AB}, {0x26BD, 0x26BE}, {0x26C4, 0x26C5}, {0x26CE, 0x26CE},
+	{0x26D4, 0x26D4}, {0x26EA, 0x26EA}, {0x26F2, 0x26F3}, {0x26F5, 0x26F5},
+	{0x26FA, 0x26FA}, {0x26FD, 0x26FD}, {0x2705, 0x2705}, {0x270A, 0x270B},
+	{0x2728, 0x2728}, {0x274C, 0x274C}, {0x274E, 0x274E}, {0x2753, 0x2755},
+	{0x2757, 0x2757}, {0x2795, 0x2797}, {0x27B0, 0x27B0}, {0x27BF, 0x27BF},
+	{0x2B1B, 0x2B1C}, {0x2B50, 0x2B50}, {0x2B55, 0x2B55}, {0x2E80, 0x303E},
+	{0x3041, 0x33FF}, {0x3400, 0x4DBF}, {0x4E00, 0x9FFF}, {0xA000, 0xA4CF},
+	{0xA960, 0xA97F}, {0xAC00, 0xD7A3}, {0xF900, 0xFAFF}, {0xFE10, 0xFE19},
+	{0xFE30, 0xFE6F}, {0xFF00, 0xFF60}, {0xFFE0, 0xFFE6}, {0x16FE0, 0x16FE4},
+	{0x17000, 0x18CFF}, {0x1B000, 0x1B2FF}, {0x1F004, 0x1F004}, {0x1F0CF, 0x1F0CF},
+	{0x1F18E, 0x1F18E}, {0x1F191, 0x1F19A}, {0x1F200, 0x1F202}, {0x1F210, 0x1F23B},
+	{0x1F240, 0x1F248}, {0x1F250, 0x1F251}, {0x1F260, 0x1F265}, {0x1F300, 0x1F320},
+	{0x1F32D, 0x1F335}, {0x1F337, 0x1F37C}, {0x1F37E, 0x1F393}, {0x1F3A0, 0x1F3CA},
+	{0x1F3CF, 0x1F3D3}, {0x1F3E0, 0x1F3F0}, {0x1F3F4, 0x1F3F4}, {0x1F3F8, 0x1F43E},
+	{0x1F440, 0x1F440}, {0x1F442, 0x1F4FC}, {0x1F4FF, 0x1F53D}, {0x1F54B, 0x1F54E},
+	{0x1F550, 0x1F567}, {0x1F57A, 0x1F57A}, {0x1F595, 0x1F596}, {0x1F5A4, 0x1F5A4},
+	{0x1F5FB, 0x1F64F}, {0x1F680, 0x1F6C5}, {0x1F6CC, 0x1F6CC}, {0x1F6D0, 0x1F6D2},
+	{0x1F6D5, 0x1F6D7}, {0x1F6DC, 0x1F6DF}, {0x1F6EB, 0x1F6EC}, {0x1F6F4, 0x1F6FC},
+	{0x1F7E0, 0x1F7EB}, {0x1F7F0, 0x1F7F0}, {0x1F90C, 0x1F93A}, {0x1F93C, 0x1F945},
+	{0x1F947, 0x1F9FF}, {0x1FA70, 0x1FAFF}, {0x20000, 0x2FFFD}, {0x30000, 0x3FFFD},
+}
+
+// zeroWidthRanges are runes that do not advance the cursor in a terminal in
+// addition to the non-spacing and enclosing marks (Mn, Me) and format
+// characters (Cf).
+var zeroWidthRanges = []runeRange{
+	{0x1160, 0x11FF}, // Hangul Jungseong and Jongseong (conjoining jamo).
+	{0x200B, 0x200F}, // Zero width space, (non-)joiners and direction marks.
+	{0xD7B0, 0xD7FF}, // Hangul Jamo Extended-B.
+	{0xFE00, 0xFE0F}, // Variation selectors.
+	{0xE0100, 0xE01EF},
+}
+
+func inRanges(r rune, ranges []runeRange) bool {
+	i := sort.Search(len(ranges), func(i int) bool {
+		return ranges[i].last >= r
+	})
+	return i < len(ranges) && ranges[i].first <= r
+}
+
+// IsWide returns true if r occupies two columns in a terminal (East Asian wide
+// and full-width characters and most emoji).
+func IsWide(r rune) bool {
+	return r >= 0x1100 && inRanges(r, wideRanges)
+}
+
+// IsCombining returns true if r has no width of its own but attaches to the
+// character before it, for example combining diacritical marks, the zero width
+// joiner and variation selectors.
+func IsCombining(r rune) bool {
+	if r < 0x0300 {
+		return false
+	}
+	return unicode.In(r, unicode.Mn, unicode.Me) || inRanges(r, zeroWidthRanges)
+}
+
+// RuneWidth returns the number of terminal columns r occupies: 0 for control
+// characters, combining marks and other zero width runes, 2 for wide
+// characters and 1 for everything else.
+func RuneWidth(r rune) int {
+	switch {
+	case r < 0x20, r >= 0x7f && r < 0xa0:
+		return 0
+	case r < 0x0300:
+		return 1
+	case IsCombining(r), unicode.Is(unicode.Cf, r):
+		return 0
+	case IsWide(r):
+		return 2
+	}
+	return 1
+}
+
+// StringWidth returns the number of terminal columns s occupies, the sum of
+// the width of each grapheme cluster (see GraphemeWidth). Line breaks are not
+// treated specially, see MaximumLineWidth for multi-line text.
+func StringWidth(s string) int {
+	runes := []rune(s)
+	w := 0
+	for len(runes) > 0 {
+		n := graphemeLength(runes)
+		w += clusterWidth(runes[:n])
+		runes = runes[n:]
+	}
+	return w
+}
+
+// RowAndColumnWidth is the display width variant of RowAndColumnCount.
+// Returns x, y (columns, rows) where x is the width of the widest line in
+// terminal columns.
+func RowAndColumnWidth(text string) (int, int) {
+	columns := 0
+	rows := 0
+	s := bufio.NewScanner(strings.NewReader(text))
+	for s.Scan() {
+		if w := StringWidth(s.Text()); w > columns {
+			columns = w
+		}
+		rows++
+	}
+	return columns, rows
+}
+
+// MaximumLineWidth is the display width variant of MaximumLineLength, returns
+// the width in terminal columns of the widest line.
+func MaximumLineWidth(text string) int {
+	columns, _ := RowAndColumnWidth(text)
+	return columns
+}
+
+// CutLineShortWidth is the display width variant of CutLineShort. Cuts line
+// to at most maxWidth terminal columns, adding dots (…) as the last column if
+// addThreeDots is true. Combining marks stay with the character they belong
+// to and a wide character that does not fit entirely is removed. The line is
+// never cut within a grapheme cluster.
+func CutLineShortWidth(line string, maxWidth int, addThreeDots bool) string {
+	if StringWidth(line) <= maxWidth {
+		return line
+	}
+	if maxWidth <= 0 {
+		return ""
+	}
+	limit := maxWidth
+	if addThreeDots {
+		limit--
+	}
+	var sb strings.Builder
+	w := 0
+	runes := []rune(line)
+	for len(runes) > 0 {
+		n := graphemeLength(runes)
+		cw := clusterWidth(runes[:n])
+		if w+cw > limit {
+			break
+		}
+		w += cw
+		sb.WriteString(string(runes[:n]))
+		runes = runes[n:]
+	}
+	if addThreeDots {
+		sb.WriteRune('…')
+	}
+	return sb.String()
+}
+
+// CutLinesShortWidth is the display width variant of CutLinesShort.
+func CutLinesShortWidth(text string, maxWidth int, trimTrailingSpace bool) string {
+	var sb strings.Builder
+	s := bufio.NewScanner(strings.NewReader(text))
+	for s.Scan() {
+		line := CutLineShortWidth(s.Text(), maxWidth, false)
+		if trimTrailingSpace {
+			line = strings.TrimRightFunc(line, unicode.IsSpace)
+		}
+		sb.WriteString(line)
+		sb.WriteString(LineBreak)
+	}
+	return sb.String()
+}
+
+// cropLeftWidth removes n terminal columns from the beginning of line, one
+// grapheme cluster at a time. If only half of a wide character would be
+// removed, it is replaced by a space.
+func cropLeftWidth(line []rune, n int) []rune {
+	w := 0
+	i := 0
+	for i < len(line) && w < n {
+		l := graphemeLength(line[i:])
+		w += clusterWidth(line[i : i+l])
+		i += l
+	}
+	if w > n {
+		return append([]rune{' '}, line[i:]...)
+	}
+	return line[i:]
+}
@@ -0,0 +1,70 @@
+package blox_test
+
+import (
+	"testing"
+
+	"github.com/sa6mwa/blox"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRuneWidth(t *testing.T) {
+	assert.Equal(t, 1, blox.RuneWidth('A'))
+	assert.Equal(t, 1, blox.RuneWidth('Å'))
+	assert.Equal(t, 0, blox.RuneWidth('\t'))
+	assert.Equal(t, 0, blox.RuneWidth('\u0301'))
+	assert.Equal(t, 0, blox.RuneWidth('\u200d'))
+	assert.Equal(t, 2, blox.RuneWidth('日'))
+	assert.Equal(t, 2, blox.RuneWidth('Ａ'))
+	assert.Equal(t, 2, blox.RuneWidth('😀'))
+	assert.Equal(t, 1, blox.RuneWidth('─'))
+	assert.Equal(t, 7, blox.StringWidth("日本語á"))
+}
+
+func TestWidthHelpers(t *testing.T) {
+	text := "日本語" + blox.LineBreak + "abcd" + blox.LineBreak + "é"
+	col, row := blox.RowAndColumnWidth(text)
+	assert.Equal(t, 6, col)
+	assert.Equal(t, 3, row)
+	assert.Equal(t, 6, blox.MaximumLineWidth(text))
+	assert.Equal(t, 4, blox.MaximumLineLength(text))
+
+	assert.Equal(t, "日本", blox.CutLineShortWidth("日本語", 5, false))
+	assert.Equal(t, "日本…", blox.CutLineShortWidth("日本語", 5, true))
+	assert.Equal(t, "éé", blox.CutLineShortWidth("ééé", 2, false))
+	assert.Equal(t, "日本語", blox.CutLineShortWidth("日本語", 6, true))
+	assert.Equal(t, "日"+blox.LineBreak+"abc"+blox.LineBreak+"é"+blox.LineBreak, blox.CutLinesShortWidth(text, 3, true))
+}
+
+func TestPutCharWide(t *testing.T) {
+	b := blox.New().SetColumnsAndRows(5, 1).Trim()
+	b.PutText("日本語")
+	assert.Equal(t, []rune{'日', blox.ContinuationCell, '本', blox.ContinuationCell, ' '}, b.Canvas)
+	assert.Equal(t, "日本"+blox.LineBreak, b.String())
+
+	// Overwriting half a wide character blanks the other half.
+	b.Move(1, 0).PutChar('x')
+	assert.Equal(t, " x本"+blox.LineBreak, b.String())
+	b.Move(2, 0).PutChar('y')
+	assert.Equal(t, " xy"+blox.LineBreak, b.String())
+}
+
+func TestPutCharCombining(t *testing.T) {
+	b := blox.New().SetColumnsAndRows(3, 2).Trim()
+	b.PutText("éäbç").PutText("́x")
+	assert.Equal(t, "éäb"+blox.LineBreak+"x"+blox.LineBreak, b.String())
+	assert.Equal(t, []rune{'́'}, b.Cells()[0][0].Combining)
+
+	// Overwriting a cell drops its combining marks.
+	b.Move(0, 0).PutChar('E')
+	assert.Equal(t, "Eäb"+blox.LineBreak+"x"+blox.LineBreak, b.String())
+}
+
+func ExampleBlox_PutTextRightAligned_wide() {
+	b := blox.New().Trim().SetColumnsAndRows(12, 3)
+	b.PutText("Name:"+blox.LineBreak+"Total:").Move(0, 0).
+		PutTextRightAligned("東京" + blox.LineBreak + "1234")
+	b.PrintCanvas()
+	// Output:
+	// Name:   東京
+	// Total:  1234
+}
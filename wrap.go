@@ -0,0 +1,102 @@
+package blox
+
+import "unicode"
+
+// PutTextWrapped writes text at the cursor position word-wrapped to width
+// terminal columns (see StringWidth). If width is 0 or less, the text is
+// wrapped at the remaining width from the cursor to the right edge of the
+// canvas. Lines are separated by LineSpacing like PutText and writing stops
+// before row cursor+height, or at the bottom of the canvas if height is 0 or
+// less. Words longer than width are broken where they hit the edge and line
+// breaks in text are kept.
+//
+// Returns the text that did not fit (an empty string if all of it did),
+// starting at the first word that was not written, which can be passed to
+// PutTextWrapped again to continue on the next page. The cursor is left at
+// the original column on the row after the last line written.
+func (b *Blox) PutTextWrapped(text string, width int, height int) string {
+	if b.Rows == 0 || b.Columns == 0 {
+		return text
+	}
+	originX, originY := b.Cursor.X, b.Cursor.Y
+	if width <= 0 {
+		width = b.Columns - originX - b.originX
+	}
+	if width <= 0 || b.pastCanvas() {
+		return text
+	}
+	bottom := b.Rows - b.originY
+	if height > 0 && originY+height < bottom {
+		bottom = originY + height
+	}
+	runes := []rune(text)
+	y := originY
+	for len(runes) > 0 && y < bottom {
+		line, rest, wrapped := wrapRunes(runes, width)
+		b.Move(originX, y).PutLine(line)
+		if wrapped {
+			for len(rest) > 0 && isWrapSpace(rest[0]) {
+				rest = rest[1:]
+			}
+		}
+		runes = rest
+		y += b.LineSpacing
+	}
+	b.Move(originX, y)
+	return string(runes)
+}
+
+// isWrapSpace returns true if lines can be wrapped at r (white space that is
+// not a line break or a no-break space).
+func isWrapSpace(r rune) bool {
+	return r != '\n' && r != '\r' && r != 0xA0 && r != 0x202F && unicode.IsSpace(r)
+}
+
+// wrapRunes returns the first line of runes that fits within width terminal
+// columns and the runes following it. The line is broken at a line break, at
+// the last white space that fits (wrapped is true) or in the middle of a word
+// longer than width (wrapped is true). Trailing white space of a wrapped line
+// is removed.
+func wrapRunes(runes []rune, width int) (line []rune, rest []rune, wrapped bool) {
+	w := 0
+	breakAt := -1 // Where the line ends if wrapped at the last white space.
+	resume := -1  // Where the next line starts if wrapped at breakAt.
+	for i := 0; i < len(runes); {
+		switch runes[i] {
+		case '\n':
+			return runes[:i], runes[i+1:], false
+		case '\r':
+			if i+1 < len(runes) && runes[i+1] == '\n' {
+				return runes[:i], runes[i+2:], false
+			}
+			return runes[:i], runes[i+1:], false
+		}
+		n := graphemeLength(runes[i:])
+		cw := clusterWidth(runes[i : i+n])
+		if isWrapSpace(runes[i]) {
+			if i > 0 && !isWrapSpace(runes[i-1]) {
+				breakAt = i
+			}
+			if w+cw > width {
+				end := i
+				if breakAt >= 0 {
+					end = breakAt
+				}
+				return runes[:end], runes[i:], true
+			}
+			resume = i + n
+		} else if w+cw > width {
+			switch {
+			case breakAt >= 0:
+				return runes[:breakAt], runes[resume:], true
+			case i == 0:
+				// A single character wider than width, put it anyway.
+				return runes[:n], runes[n:], true
+			}
+			return runes[:i], runes[i:], true
+		}
+		w += cw
+		i += n
+	}
+	return runes, nil, false
+}
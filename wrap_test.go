@@ -0,0 +1,53 @@
+package blox_test
+
+import (
+	"testing"
+
+	"github.com/sa6mwa/blox"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPutTextWrapped(t *testing.T) {
+	b := blox.New().SetColumnsAndRows(14, 6).Trim()
+	rest := b.Move(2, 1).PutTextWrapped("The quick brown fox jumps over the lazy dog", 0, 0)
+	assert.Empty(t, rest)
+	expect := blox.LineBreak +
+		"  The quick" + blox.LineBreak +
+		"  brown fox" + blox.LineBreak +
+		"  jumps over" + blox.LineBreak +
+		"  the lazy dog" + blox.LineBreak
+	assert.Equal(t, expect, b.String())
+	assert.Equal(t, 2, b.Cursor.X)
+	assert.Equal(t, 5, b.Cursor.Y)
+}
+
+func TestPutTextWrappedOverflow(t *testing.T) {
+	b := blox.New().SetColumnsAndRows(10, 10).Trim().SetLineSpacing(2)
+	text := "Supercalifragilistic words" + blox.LineBreak + "are broken. Rest spills over."
+	rest := b.PutTextWrapped(text, 6, 5)
+	expect := "Superc" + blox.LineBreak + blox.LineBreak +
+		"alifra" + blox.LineBreak + blox.LineBreak +
+		"gilist" + blox.LineBreak
+	assert.Equal(t, expect, b.String())
+	assert.Equal(t, "ic words"+blox.LineBreak+"are broken. Rest spills over.", rest)
+
+	// Continue on the next page.
+	page := blox.New().SetColumnsAndRows(10, 10).Trim()
+	rest = page.PutTextWrapped(rest, 6, 0)
+	assert.Empty(t, rest)
+	expect = "ic" + blox.LineBreak +
+		"words" + blox.LineBreak +
+		"are" + blox.LineBreak +
+		"broken" + blox.LineBreak +
+		". Rest" + blox.LineBreak +
+		"spills" + blox.LineBreak +
+		"over." + blox.LineBreak
+	assert.Equal(t, expect, page.String())
+}
+
+func TestPutTextWrappedWide(t *testing.T) {
+	b := blox.New().SetColumnsAndRows(5, 3).Trim()
+	rest := b.PutTextWrapped("日本語のテキスト", 0, 0)
+	assert.Equal(t, "日本"+blox.LineBreak+"語の"+blox.LineBreak+"テキ"+blox.LineBreak, b.String())
+	assert.Equal(t, "スト", rest)
+}
@@ -0,0 +1,119 @@
+package blox_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/sa6mwa/blox"
+	"github.com/stretchr/testify/assert"
+)
+
+// failingWriter accepts n writes and fails after that.
+type failingWriter struct {
+	n int
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if w.n <= 0 {
+		return 0, errors.New("disk full")
+	}
+	w.n--
+	return len(p), nil
+}
+
+// limitedWriter accepts n bytes and fails after that.
+type limitedWriter struct {
+	n int
+}
+
+func (w *limitedWriter) Write(p []byte) (int, error) {
+	if len(p) > w.n {
+		n := w.n
+		w.n = 0
+		return n, errors.New("disk full")
+	}
+	w.n -= len(p)
+	return len(p), nil
+}
+
+// countingWriter counts the calls to Write.
+type countingWriter struct {
+	writes int
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.writes++
+	return len(p), nil
+}
+
+func TestWriteTo(t *testing.T) {
+	var _ io.WriterTo = blox.New()
+	for _, trim := range []bool{false, true} {
+		b := blox.New().SetColumnsAndRows(12, 5).SetTrim(trim).
+			PutText("Hello" + blox.LineBreak + "wörld 世界")
+		b.Layer("top").Move(8, 0).PutText("top")
+		var buf bytes.Buffer
+		n, err := b.WriteTo(&buf)
+		assert.NoError(t, err)
+		assert.Equal(t, b.String(), buf.String())
+		assert.Equal(t, int64(buf.Len()), n)
+	}
+
+	b := blox.New().SetColumnsAndRows(10, 100)
+	w := &countingWriter{}
+	b.WriteTo(w)
+	assert.Equal(t, 1, w.writes, "rows are written in chunks")
+	w = &countingWriter{}
+	b.FprintCanvas(w)
+	assert.Equal(t, 1, w.writes)
+	w = &countingWriter{}
+	b.WriteANSI(w, blox.ProfileTrueColor)
+	assert.Equal(t, 1, w.writes)
+}
+
+func TestWriteToError(t *testing.T) {
+	b := blox.New().SetColumnsAndRows(3, 3).PutText("abc" + blox.LineBreak + "def")
+	n, err := b.WriteTo(&limitedWriter{n: 5})
+	assert.EqualError(t, err, "disk full")
+	assert.Equal(t, int64(5), n)
+	assert.EqualError(t, b.FprintCanvas(&failingWriter{}).Err(), "disk full")
+}
+
+func TestFprintToWriter(t *testing.T) {
+	b := blox.New().SetColumnsAndRows(10, 3).Trim().SetForeground(blox.Red).PutText("red").ResetPen()
+	var sb strings.Builder
+	assert.NoError(t, b.FprintCanvas(&sb).Err())
+	assert.Equal(t, "red"+blox.LineBreak, sb.String())
+	var buf bytes.Buffer
+	assert.NoError(t, b.WriteANSI(&buf, blox.Profile16))
+	assert.Equal(t, b.ANSI(blox.Profile16), buf.String())
+}
+
+func BenchmarkWriteTo(b *testing.B) {
+	canvas := blox.New().SetColumnsAndRows(500, 2000)
+	for y := 0; y < canvas.Rows; y += 2 {
+		canvas.Move(0, y).PutText(strings.Repeat("report ", 70))
+	}
+	w := &countingWriter{}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		canvas.WriteTo(w)
+	}
+	b.ReportMetric(float64(w.writes)/float64(b.N), "writes/op")
+}
+
+func BenchmarkFprintString(b *testing.B) {
+	canvas := blox.New().SetColumnsAndRows(500, 2000)
+	for y := 0; y < canvas.Rows; y += 2 {
+		canvas.Move(0, y).PutText(strings.Repeat("report ", 70))
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		io.WriteString(io.Discard, canvas.String())
+	}
+}